@@ -0,0 +1,113 @@
+package hexagolang
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// ASCII maps place one character per hex on a staggered grid.
+// OrientationPointy uses doubled width coordinates, a line per row and a character per column.
+// OrientationFlat uses doubled height coordinates, a line per row and two characters per column.
+//
+//	pointy    flat
+//	 a b      a   c
+//	c d e       b
+//	 f g      d   e
+
+// RenderASCII draws the hexagons as text cropped to fit them, each line ending in a newline.
+// A nil glyph draws every hex as '#', a space leaves the hex blank.
+func RenderASCII(hexes map[H]bool, o Orientation, glyph func(H) rune) string {
+	type cell struct{ x, line int }
+	cells := make(map[cell]rune)
+	minX, minLine := math.MaxInt32, math.MaxInt32
+	maxX, maxLine := math.MinInt32, math.MinInt32
+	for h, in := range hexes {
+		if !in {
+			continue
+		}
+		x, line := asciiCell(h, o)
+		c := '#'
+		if glyph != nil {
+			c = glyph(h)
+		}
+		cells[cell{x, line}] = c
+		minX, maxX = intMin(minX, x), intMax(maxX, x)
+		minLine, maxLine = intMin(minLine, line), intMax(maxLine, line)
+	}
+	if len(cells) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	for line := minLine; line <= maxLine; line++ {
+		row := make([]rune, maxX-minX+1)
+		for x := range row {
+			row[x] = ' '
+			if c, ok := cells[cell{minX + x, line}]; ok {
+				row[x] = c
+			}
+		}
+		b.WriteString(strings.TrimRight(string(row), " "))
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+// ParseASCII reads text drawn by RenderASCII, spaces are blank and every other character is a hex.
+// Hexagons are numbered from the top left, the first hex read decides which characters sit on the grid.
+// Characters off the staggered grid are an error.
+func ParseASCII(text string, o Orientation) (map[H]rune, error) {
+	result := make(map[H]rune)
+	found := false
+	dx, dl := 0, 0
+	for line, row := range strings.Split(text, "\n") {
+		for x, c := range []rune(strings.TrimRight(row, "\r")) {
+			if c == ' ' {
+				continue
+			}
+			if !found {
+				found = true
+				if o == OrientationFlat {
+					dx = x & 1
+					dl = ((x-dx)/2 + line) & 1
+				} else {
+					dx = (x + line) & 1
+				}
+			}
+			h := asciiHex(x-dx, line-dl, o)
+			if hx, hl := asciiCell(h, o); hx != x-dx || hl != line-dl {
+				return nil, fmt.Errorf("hexagolang: line %d column %d: %q is between hexagons", line+1, x+1, c)
+			}
+			result[h] = c
+		}
+	}
+	return result, nil
+}
+
+// asciiCell returns the character and line of a hex.
+func asciiCell(h H, o Orientation) (int, int) {
+	if o == OrientationFlat {
+		c := h.DoubledHeight()
+		return 2 * c.Col, c.Row
+	}
+	c := h.DoubledWidth()
+	return c.Col, c.Row
+}
+
+// asciiHex returns the hex at a character and line, rounding down off the grid.
+func asciiHex(x, line int, o Orientation) H {
+	if o == OrientationFlat {
+		col := intFloorDiv(x, 2)
+		return DoubledHeight{Col: col, Row: col + 2*intFloorDiv(line-col, 2)}.Hex()
+	}
+	return DoubledWidth{Col: line + 2*intFloorDiv(x-line, 2), Row: line}.Hex()
+}
+
+// intFloorDiv divides rounding toward negative infinity.
+func intFloorDiv(a, b int) int {
+	q := a / b
+	if (a%b != 0) && ((a < 0) != (b < 0)) {
+		q--
+	}
+	return q
+}
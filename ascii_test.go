@@ -0,0 +1,76 @@
+package hexagolang
+
+import (
+	"testing"
+)
+
+// I need to see and write hexagons as text.
+// Rational, boards in tests and terminal debugging are easier to read drawn out.
+func TestASCII(t *testing.T) {
+	area := Range(H{0, 0}, 1)
+	plan := []struct {
+		name     string
+		hexes    map[H]bool
+		o        Orientation
+		expected string
+	}{
+		{"pointy", area, OrientationPointy, " # #\n# # #\n # #\n"},
+		{"flat", area, OrientationFlat, "  #\n#   #\n  #\n#   #\n  #\n"},
+		{"pointy line", map[H]bool{{0, 0}: true, {0, 1}: true, {0, 2}: true}, OrientationPointy, "#\n #\n  #\n"},
+		{"flat line", map[H]bool{{0, 0}: true, {1, 0}: true, {2, 0}: true}, OrientationFlat, "#\n  #\n    #\n"},
+		{"empty", map[H]bool{{0, 0}: false}, OrientationPointy, ""},
+	}
+	for _, params := range plan {
+		text := RenderASCII(params.hexes, params.o, nil)
+		if text != params.expected {
+			t.Errorf("%s: expected\n%s\ngot\n%s", params.name, params.expected, text)
+		}
+		parsed, err := ParseASCII(text, params.o)
+		if err != nil {
+			t.Errorf("%s: %v", params.name, err)
+			continue
+		}
+		hexes := make(map[H]bool)
+		for h, c := range parsed {
+			hexes[h] = c == '#'
+		}
+		if again := RenderASCII(hexes, params.o, nil); again != text {
+			t.Errorf("%s: parsed as\n%s", params.name, again)
+		}
+	}
+
+	// Glyphs come back on the same hexagons, moved to the top left.
+	labels := map[H]rune{{3, -2}: 'a', {4, -2}: 'b', {2, -1}: 'c', {3, 0}: 'd', {5, -1}: 'e'}
+	glyph := func(h H) rune { return labels[h] }
+	for _, o := range []Orientation{OrientationPointy, OrientationFlat} {
+		set := make(map[H]bool)
+		for h := range labels {
+			set[h] = true
+		}
+		parsed, err := ParseASCII(RenderASCII(set, o, glyph), o)
+		if err != nil {
+			t.Fatalf("%v", err)
+		}
+		var shift D
+		for h, c := range parsed {
+			if c == 'a' {
+				shift = Subtract(h, H{3, -2})
+			}
+		}
+		if len(parsed) != len(labels) {
+			t.Errorf("expected %d hexagons, got %d", len(labels), len(parsed))
+		}
+		for h, c := range labels {
+			if result := parsed[Add(h, shift)]; result != c {
+				t.Errorf("%+v: expected %q, got %q", h, c, result)
+			}
+		}
+	}
+
+	if _, err := ParseASCII("##", OrientationPointy); err == nil {
+		t.Errorf("expected an error for hexagons side by side")
+	}
+	if _, err := ParseASCII("#\n#", OrientationFlat); err == nil {
+		t.Errorf("expected an error for hexagons on adjacent lines")
+	}
+}
@@ -0,0 +1,88 @@
+package hexagolang
+
+// Doubled coordinates as described in
+// https://www.redblobgames.com/grids/hexagons/#coordinates-doubled
+
+// DoubledWidth is a hexagon in doubled width coordinates, Col + Row is always even.
+// It suits OrientationPointy where each row steps two columns between hexagons.
+type DoubledWidth struct {
+	Col, Row int
+}
+
+// DoubledWidth converts the hex to doubled width coordinates.
+func (h H) DoubledWidth() DoubledWidth {
+	return DoubledWidth{Col: 2*h.Q + h.R, Row: h.R}
+}
+
+// Hex converts the doubled width coordinates to a hex.
+func (c DoubledWidth) Hex() H {
+	return H{Q: (c.Col - c.Row) / 2, R: c.Row}
+}
+
+// Neighbor one step in a specific direction.
+func (c DoubledWidth) Neighbor(d DirectionEnum) DoubledWidth {
+	return c.Hex().Neighbor(d).DoubledWidth()
+}
+
+// Distance returns the manhattan distance between two doubled width coordinates.
+func (c DoubledWidth) Distance(b DoubledWidth) int {
+	col, row := intAbs(c.Col-b.Col), intAbs(c.Row-b.Row)
+	return row + intMax(0, (col-row)/2)
+}
+
+// DoubledHeight is a hexagon in doubled height coordinates, Col + Row is always even.
+// It suits OrientationFlat where each column steps two rows between hexagons.
+type DoubledHeight struct {
+	Col, Row int
+}
+
+// DoubledHeight converts the hex to doubled height coordinates.
+func (h H) DoubledHeight() DoubledHeight {
+	return DoubledHeight{Col: h.Q, Row: 2*h.R + h.Q}
+}
+
+// Hex converts the doubled height coordinates to a hex.
+func (c DoubledHeight) Hex() H {
+	return H{Q: c.Col, R: (c.Row - c.Col) / 2}
+}
+
+// Neighbor one step in a specific direction.
+func (c DoubledHeight) Neighbor(d DirectionEnum) DoubledHeight {
+	return c.Hex().Neighbor(d).DoubledHeight()
+}
+
+// Distance returns the manhattan distance between two doubled height coordinates.
+func (c DoubledHeight) Distance(b DoubledHeight) int {
+	col, row := intAbs(c.Col-b.Col), intAbs(c.Row-b.Row)
+	return col + intMax(0, (row-col)/2)
+}
+
+// CenterForDoubledWidth returns the point at the center of the doubled width hex based on the layout.
+func (l Layout) CenterForDoubledWidth(c DoubledWidth) F {
+	q, r :=
+		float64(c.Col-c.Row)/2.,
+		float64(c.Row)
+	x := (l.m.f[0]*q + l.m.f[1]*r) * l.Radius.X
+	y := (l.m.f[2]*q + l.m.f[3]*r) * l.Radius.Y
+	return F{x + l.Origin.X, y + l.Origin.Y}
+}
+
+// DoubledWidthFor returns the doubled width hex under a point.
+func (l Layout) DoubledWidthFor(f F) DoubledWidth {
+	return l.HexFor(f).DoubledWidth()
+}
+
+// CenterForDoubledHeight returns the point at the center of the doubled height hex based on the layout.
+func (l Layout) CenterForDoubledHeight(c DoubledHeight) F {
+	q, r :=
+		float64(c.Col),
+		float64(c.Row-c.Col)/2.
+	x := (l.m.f[0]*q + l.m.f[1]*r) * l.Radius.X
+	y := (l.m.f[2]*q + l.m.f[3]*r) * l.Radius.Y
+	return F{x + l.Origin.X, y + l.Origin.Y}
+}
+
+// DoubledHeightFor returns the doubled height hex under a point.
+func (l Layout) DoubledHeightFor(f F) DoubledHeight {
+	return l.HexFor(f).DoubledHeight()
+}
@@ -0,0 +1,83 @@
+package hexagolang
+
+import (
+	"testing"
+)
+
+// I need to translate between doubled coordinates and hex coordinates.
+// Rational, rectangular boards are easiest to store in doubled coordinates.
+func TestDoubled(t *testing.T) {
+	plan := []struct {
+		h  H
+		dw DoubledWidth
+		dh DoubledHeight
+	}{
+		{H{0, 0}, DoubledWidth{0, 0}, DoubledHeight{0, 0}},
+		{H{1, 0}, DoubledWidth{2, 0}, DoubledHeight{1, 1}},
+		{H{0, 1}, DoubledWidth{1, 1}, DoubledHeight{0, 2}},
+		{H{-2, 3}, DoubledWidth{-1, 3}, DoubledHeight{-2, 4}},
+		{H{3, -5}, DoubledWidth{1, -5}, DoubledHeight{3, -7}},
+	}
+	for tc, expected := range plan {
+		if result := expected.h.DoubledWidth(); result != expected.dw {
+			t.Errorf("index %d: doubled width of %+v expected %+v, got %+v", tc, expected.h, expected.dw, result)
+		}
+		if result := expected.dw.Hex(); result != expected.h {
+			t.Errorf("index %d: hex of %+v expected %+v, got %+v", tc, expected.dw, expected.h, result)
+		}
+		if result := expected.h.DoubledHeight(); result != expected.dh {
+			t.Errorf("index %d: doubled height of %+v expected %+v, got %+v", tc, expected.h, expected.dh, result)
+		}
+		if result := expected.dh.Hex(); result != expected.h {
+			t.Errorf("index %d: hex of %+v expected %+v, got %+v", tc, expected.dh, expected.h, result)
+		}
+	}
+
+	origin := H{2, -1}
+	for h := range Range(origin, 4) {
+		dist := Length(Subtract(h, origin))
+		if result := h.DoubledWidth().Distance(origin.DoubledWidth()); result != dist {
+			t.Errorf("doubled width distance %+v to %+v expected %d, got %d", h, origin, dist, result)
+		}
+		if result := h.DoubledHeight().Distance(origin.DoubledHeight()); result != dist {
+			t.Errorf("doubled height distance %+v to %+v expected %d, got %d", h, origin, dist, result)
+		}
+		for d := DirectionPosQ; d < DirectionUndefined; d++ {
+			if result := h.DoubledWidth().Neighbor(d); result != h.Neighbor(d).DoubledWidth() {
+				t.Errorf("doubled width neighbor %s of %+v gave %+v", d, h, result)
+			}
+			if result := h.DoubledHeight().Neighbor(d); result != h.Neighbor(d).DoubledHeight() {
+				t.Errorf("doubled height neighbor %s of %+v gave %+v", d, h, result)
+			}
+		}
+	}
+}
+
+// I need doubled coordinates to land on the same pixels as hex coordinates.
+// Rational, both coordinate systems draw the same grid.
+func TestDoubledScreenConversion(t *testing.T) {
+	layouts := []Layout{
+		MakeLayout(F{10, 10}, F{5, -3}, OrientationPointy),
+		MakeLayout(F{12, 8}, F{0, 0}, OrientationFlat),
+	}
+	for tc, layout := range layouts {
+		for h := range Range(H{0, 0}, 3) {
+			center := layout.CenterFor(h)
+			for _, result := range []F{
+				layout.CenterForDoubledWidth(h.DoubledWidth()),
+				layout.CenterForDoubledHeight(h.DoubledHeight()),
+			} {
+				delta := center.Subtract(result)
+				if -0.0001 > delta.X || delta.X > 0.0001 || -0.0001 > delta.Y || delta.Y > 0.0001 {
+					t.Errorf("index %d: %+v expected %+v, got %+v", tc, h, center, result)
+				}
+			}
+			if result := layout.DoubledWidthFor(center); result != h.DoubledWidth() {
+				t.Errorf("index %d: doubled width for %+v expected %+v, got %+v", tc, center, h.DoubledWidth(), result)
+			}
+			if result := layout.DoubledHeightFor(center); result != h.DoubledHeight() {
+				t.Errorf("index %d: doubled height for %+v expected %+v, got %+v", tc, center, h.DoubledHeight(), result)
+			}
+		}
+	}
+}
@@ -0,0 +1,139 @@
+package hexagolang
+
+// Edge and vertex coordinates as described in
+// https://www.redblobgames.com/grids/parts/#hexagon-coordinates
+
+// Edge is a side shared by two hexagons.
+// In canonical form Side is DirectionPosQ, DirectionNegR or DirectionPosS, use MakeEdge to build one.
+type Edge struct {
+	H    H
+	Side DirectionEnum
+}
+
+// MakeEdge returns the canonical edge on side d of h.
+// An undefined side gives the undefined edge, Edge{Side: DirectionUndefined}.
+func MakeEdge(h H, d DirectionEnum) Edge {
+	if d < DirectionPosQ || d >= DirectionUndefined {
+		return Edge{Side: DirectionUndefined}
+	}
+	if d >= DirectionNegQ {
+		return Edge{h.Neighbor(d), d.Opposite()}
+	}
+	return Edge{h, d}
+}
+
+// Hexes returns the two hexagons on either side of the edge.
+func (e Edge) Hexes() []H {
+	return []H{e.H, e.H.Neighbor(e.Side)}
+}
+
+// Vertices returns the two corners at the ends of the edge.
+func (e Edge) Vertices() []Vertex {
+	return []Vertex{
+		MakeVertex(e.H, Diagonal(e.Side.Rotate(-1))),
+		MakeVertex(e.H, Diagonal(e.Side)),
+	}
+}
+
+// Neighbors returns the four edges sharing a corner with the edge.
+func (e Edge) Neighbors() []Edge {
+	result := make([]Edge, 0, 4)
+	for _, v := range e.Vertices() {
+		for _, other := range v.Edges() {
+			if other != e {
+				result = append(result, other)
+			}
+		}
+	}
+	return result
+}
+
+// Vertex is a corner shared by three hexagons.
+// In canonical form Corner is DiagonalPosQ or DiagonalNegR, use MakeVertex to build one.
+type Vertex struct {
+	H      H
+	Corner Diagonal
+}
+
+// MakeVertex returns the canonical vertex at corner d of h.
+// An undefined corner gives the undefined vertex, Vertex{Corner: DiagonalUndefined}.
+func MakeVertex(h H, d Diagonal) Vertex {
+	if d < DiagonalPosQ || d >= DiagonalUndefined {
+		return Vertex{Corner: DiagonalUndefined}
+	}
+	// The corner is shared with the neighbor in direction d, where it sits two corners along.
+	for d > DiagonalNegR {
+		h = h.Neighbor(DirectionEnum(d))
+		d = (d + 2) % 6
+	}
+	return Vertex{h, d}
+}
+
+// Hexes returns the three hexagons meeting at the vertex.
+func (v Vertex) Hexes() []H {
+	return []H{
+		v.H,
+		v.H.Neighbor(DirectionEnum(v.Corner)),
+		v.H.Neighbor(DirectionEnum(v.Corner).Rotate(1)),
+	}
+}
+
+// Edges returns the three edges meeting at the vertex.
+func (v Vertex) Edges() []Edge {
+	side := DirectionEnum(v.Corner)
+	return []Edge{
+		MakeEdge(v.H, side),
+		MakeEdge(v.H, side.Rotate(1)),
+		MakeEdge(v.H.Neighbor(side), side.Rotate(2)),
+	}
+}
+
+// Neighbors returns the three vertices one edge away from the vertex.
+func (v Vertex) Neighbors() []Vertex {
+	return []Vertex{
+		MakeVertex(v.H, v.Corner.Rotate(-1)),
+		MakeVertex(v.H, v.Corner.Rotate(1)),
+		MakeVertex(v.H.Neighbor(DirectionEnum(v.Corner)), v.Corner.Rotate(1)),
+	}
+}
+
+// Edges returns the six edges of the hex in direction order.
+func (h H) Edges() []Edge {
+	result := make([]Edge, 6)
+	for k := range result {
+		result[k] = MakeEdge(h, DirectionEnum(k))
+	}
+	return result
+}
+
+// Vertices returns the six corners of the hex in diagonal order.
+func (h H) Vertices() []Vertex {
+	result := make([]Vertex, 6)
+	for k := range result {
+		result[k] = MakeVertex(h, Diagonal(k))
+	}
+	return result
+}
+
+// CenterForEdge returns the point at the middle of the edge based on the layout.
+func (l Layout) CenterForEdge(e Edge) F {
+	a, b := l.EndsForEdge(e)
+	return F{(a.X + b.X) / 2., (a.Y + b.Y) / 2.}
+}
+
+// EndsForEdge returns the points at either end of the edge based on the layout.
+func (l Layout) EndsForEdge(e Edge) (F, F) {
+	v := e.Vertices()
+	return l.CenterForVertex(v[0]), l.CenterForVertex(v[1])
+}
+
+// CenterForVertex returns the point of the vertex based on the layout.
+func (l Layout) CenterForVertex(v Vertex) F {
+	// A corner is a third of the way to the diagonal neighbor.
+	h, d := v.H.Fractional(), DiagonalDelta(v.Corner)
+	return l.CenterForFractional(FH{
+		Q: h.Q + float64(d.Q)/3.,
+		R: h.R + float64(d.R)/3.,
+		S: h.S + float64(d.S)/3.,
+	})
+}
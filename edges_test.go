@@ -0,0 +1,123 @@
+package hexagolang
+
+import (
+	"testing"
+)
+
+// I need to name the sides and corners of a hex.
+// Rational, walls and rivers sit on sides and settlements sit on corners.
+func TestEdgesAndVertices(t *testing.T) {
+	for h := range Range(H{1, -2}, 3) {
+		edges, vertices := map[Edge]bool{}, map[Vertex]bool{}
+		for k, e := range h.Edges() {
+			d := DirectionEnum(k)
+			if e != MakeEdge(h.Neighbor(d), (d+3)%6) {
+				t.Errorf("edge %s of %+v doesn't match its neighbor", d, h)
+			}
+			if e.Side > DirectionPosS {
+				t.Errorf("edge %+v isn't canonical", e)
+			}
+			if hexes := e.Hexes(); !(hexes[0] == h || hexes[1] == h) {
+				t.Errorf("edge %+v doesn't touch %+v", e, h)
+			}
+			for _, v := range e.Vertices() {
+				found := false
+				for _, other := range v.Edges() {
+					found = found || other == e
+				}
+				if !found {
+					t.Errorf("vertex %+v doesn't list edge %+v", v, e)
+				}
+			}
+			if n := e.Neighbors(); len(n) != 4 {
+				t.Errorf("expected 4 neighbors for %+v, got %+v", e, n)
+			}
+			edges[e] = true
+		}
+		for k, v := range h.Vertices() {
+			i := Diagonal(k)
+			if v != MakeVertex(h.Neighbor(DirectionEnum(i)), (i+2)%6) ||
+				v != MakeVertex(h.Neighbor(DirectionEnum(i+1)%6), (i+4)%6) {
+				t.Errorf("vertex %s of %+v doesn't match its neighbors", i, h)
+			}
+			if v.Corner > DiagonalNegR {
+				t.Errorf("vertex %+v isn't canonical", v)
+			}
+			for _, other := range v.Hexes() {
+				found := false
+				for _, w := range other.Vertices() {
+					found = found || w == v
+				}
+				if !found {
+					t.Errorf("hex %+v doesn't list vertex %+v", other, v)
+				}
+			}
+			for _, n := range v.Neighbors() {
+				shared := 0
+				for _, a := range v.Edges() {
+					for _, b := range n.Edges() {
+						if a == b {
+							shared++
+						}
+					}
+				}
+				if shared != 1 {
+					t.Errorf("vertex %+v and %+v share %d edges", v, n, shared)
+				}
+			}
+			vertices[v] = true
+		}
+		if len(edges) != 6 || len(vertices) != 6 {
+			t.Errorf("expected 6 edges and 6 vertices for %+v, got %d and %d", h, len(edges), len(vertices))
+		}
+	}
+}
+
+// I need sides and corners that don't exist to stay undefined.
+// Rational, wrapping an undefined side would quietly name a real one.
+func TestUndefinedEdgesAndVertices(t *testing.T) {
+	for _, d := range []int{-1, 6, 7} {
+		if e := MakeEdge(H{2, -1}, DirectionEnum(d)); e != (Edge{Side: DirectionUndefined}) {
+			t.Errorf("side %d: expected the undefined edge, got %+v", d, e)
+		}
+		if v := MakeVertex(H{2, -1}, Diagonal(d)); v != (Vertex{Corner: DiagonalUndefined}) {
+			t.Errorf("corner %d: expected the undefined vertex, got %+v", d, v)
+		}
+	}
+}
+
+// I need the screen position of sides and corners.
+// Rational, roads and settlements are drawn on the grid.
+func TestEdgeAndVertexScreenConversion(t *testing.T) {
+	layouts := []Layout{
+		MakeLayout(F{10, 10}, F{0, 0}, OrientationPointy),
+		MakeLayout(F{12, 8}, F{5, 5}, OrientationFlat),
+	}
+	near := func(a, b F) bool {
+		d := a.Subtract(b)
+		return -0.0001 < d.X && d.X < 0.0001 && -0.0001 < d.Y && d.Y < 0.0001
+	}
+	for tc, layout := range layouts {
+		for h := range Range(H{0, 0}, 2) {
+			corners := layout.Vertices(h)[:6]
+			for _, v := range h.Vertices() {
+				p := layout.CenterForVertex(v)
+				found := false
+				for _, c := range corners {
+					found = found || near(c, p)
+				}
+				if !found {
+					t.Errorf("index %d: vertex %+v at %+v isn't a corner of %+v", tc, v, p, h)
+				}
+			}
+			for _, e := range h.Edges() {
+				hexes := e.Hexes()
+				a, b := layout.CenterFor(hexes[0]), layout.CenterFor(hexes[1])
+				mid := F{(a.X + b.X) / 2, (a.Y + b.Y) / 2}
+				if result := layout.CenterForEdge(e); !near(result, mid) {
+					t.Errorf("index %d: edge %+v expected %+v, got %+v", tc, e, mid, result)
+				}
+			}
+		}
+	}
+}
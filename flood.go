@@ -0,0 +1,66 @@
+package hexagolang
+
+// FloodFill returns the hexagons connected to start through neighbors matching pred.
+// The result is empty when start doesn't match. The fill only stops when nothing
+// is left to explore, so pred must be false outside a finite map.
+func FloodFill(start H, pred func(H) bool) Region {
+	return floodFill(start, pred, false)
+}
+
+// FloodFillDiagonal is FloodFill where hexagons sharing a diagonal also connect.
+func FloodFillDiagonal(start H, pred func(H) bool) Region {
+	return floodFill(start, pred, true)
+}
+
+// Components splits the set into connected groups labeled by their index.
+// Groups are ordered by their first hex by row then column.
+func Components(set Region) []Region {
+	return components(set, false)
+}
+
+// ComponentsDiagonal is Components where hexagons sharing a diagonal also connect.
+func ComponentsDiagonal(set Region) []Region {
+	return components(set, true)
+}
+
+func floodFill(start H, pred func(H) bool, diagonal bool) Region {
+	result := make(Region)
+	if !pred(start) {
+		return result
+	}
+	result[start] = true
+	stack := []H{start}
+	visit := func(n H) {
+		if !result[n] && pred(n) {
+			result[n] = true
+			stack = append(stack, n)
+		}
+	}
+	for len(stack) > 0 {
+		h := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for d := DirectionPosQ; d < DirectionUndefined; d++ {
+			visit(h.Neighbor(d))
+			if diagonal {
+				visit(h.DiagonalNeighbor(Diagonal(d)))
+			}
+		}
+	}
+	return result
+}
+
+func components(set Region, diagonal bool) []Region {
+	var result []Region
+	seen := make(Region, len(set))
+	for _, h := range set.Hexes() {
+		if seen[h] {
+			continue
+		}
+		group := floodFill(h, set.Contains, diagonal)
+		for g := range group {
+			seen[g] = true
+		}
+		result = append(result, group)
+	}
+	return result
+}
@@ -0,0 +1,58 @@
+package hexagolang
+
+import (
+	"testing"
+)
+
+// I need to know which hexagons are connected.
+// Rational, map validation looks for unreachable land and counts islands.
+func TestFloodFill(t *testing.T) {
+	land := MakeRegion(
+		H{0, 0}, H{1, 0}, H{1, 1}, // an island
+		H{3, -1},           // touches H{1, 0} only by a diagonal
+		H{-5, 0}, H{-5, 1}, // another island
+	)
+
+	plan := []struct {
+		name     string
+		start    H
+		diagonal bool
+		size     int
+	}{
+		{"island", H{1, 1}, false, 3},
+		{"outside", H{2, 2}, false, 0},
+		{"alone", H{3, -1}, false, 1},
+		{"diagonal", H{3, -1}, true, 4},
+		{"far island", H{-5, 0}, true, 2},
+	}
+	for _, params := range plan {
+		fill := FloodFill
+		if params.diagonal {
+			fill = FloodFillDiagonal
+		}
+		result := fill(params.start, land.Contains)
+		if len(result) != params.size {
+			t.Errorf("%s: expected %d hexagons, got %+v", params.name, params.size, result.Hexes())
+		}
+		if !land.ContainsRegion(result) {
+			t.Errorf("%s: filled outside the land %+v", params.name, result.Hexes())
+		}
+	}
+
+	sizes := func(groups []Region) []int {
+		var result []int
+		for _, g := range groups {
+			result = append(result, len(g))
+		}
+		return result
+	}
+	if got := sizes(Components(land)); len(got) != 3 || got[0] != 1 || got[1] != 2 || got[2] != 3 {
+		t.Errorf("components: expected [1 2 3], got %v", got)
+	}
+	if got := sizes(ComponentsDiagonal(land)); len(got) != 2 || got[0] != 4 || got[1] != 2 {
+		t.Errorf("diagonal components: expected [4 2], got %v", got)
+	}
+	if got := Components(Region{}); len(got) != 0 {
+		t.Errorf("empty: expected no components, got %v", got)
+	}
+}
@@ -0,0 +1,149 @@
+package hexagolang
+
+import (
+	"math"
+	"sort"
+)
+
+// Visible reports if nothing blocks the line from one hex to another.
+// The hexagons at either end of the line never block. A line grazing the
+// corner between two hexagons is only blocked when both of them block, so
+// mirrored maps see mirrored hexagons and a sees b whenever b sees a.
+func Visible(from, to H, blocks func(H) bool) bool {
+	return !lineBlocked(from, to, 1, blocks) || !lineBlocked(from, to, -1, blocks)
+}
+
+// SymmetricVisible reports if the line is clear in both directions, so a sees b whenever b sees a.
+// Visible already is symmetric, SymmetricVisible is kept for callers that want to say so.
+func SymmetricVisible(a, b H, blocks func(H) bool) bool {
+	return Visible(a, b, blocks) && Visible(b, a, blocks)
+}
+
+// lineNudge moves a line off the corners between hexagons. The parts differ
+// so that no two coordinates of a point tie when rounding.
+var lineNudge = FH{1e-6, 2e-6, -3e-6}
+
+// lineBlocked reports if a hex strictly between from and to blocks the line
+// moved to one side of the corners it grazes, picked by the sign of side.
+func lineBlocked(from, to H, side float64, blocks func(H) bool) bool {
+	n := Length(Subtract(to, from))
+	nudge := FH{lineNudge.Q * side, lineNudge.R * side, lineNudge.S * side}
+	fa, fb := from.Fractional(), to.Fractional()
+	fa = FH{fa.Q + nudge.Q, fa.R + nudge.R, fa.S + nudge.S}
+	fb = FH{fb.Q + nudge.Q, fb.R + nudge.R, fb.S + nudge.S}
+	for k := 1; k < n; k++ {
+		if blocks(Lerp(fa, fb, float64(k)/float64(n)).Round()) {
+			return true
+		}
+	}
+	return false
+}
+
+// FieldOfView returns the hexagons within radius that can be seen from origin.
+// Each ring is shadowcast by the blocking hexagons of the rings inside it and
+// the hexagons left in the light are confirmed with Visible, so FieldOfView
+// agrees with Visible. Blocking hexagons are seen themselves.
+// Visible is symmetric, so b is in the field of view of a exactly when a is in the field of view of b.
+func FieldOfView(origin H, radius int, blocks func(H) bool) map[H]bool {
+	result := map[H]bool{origin: true}
+	var shadows shadowList
+	for rad := 1; rad <= radius; rad++ {
+		var casting []H
+		for h := range Ring(origin, rad) {
+			if shadows.covers(fovAngle(origin, h)) || !Visible(origin, h, blocks) {
+				continue
+			}
+			result[h] = true
+			if blocks(h) {
+				casting = append(casting, h)
+			}
+		}
+		// Hexagons of the same ring never shadow each other.
+		for _, h := range casting {
+			shadows = shadows.add(fovShadow(origin, h))
+		}
+	}
+	return result
+}
+
+// SymmetricFieldOfView returns the hexagons within radius that pass SymmetricVisible from origin.
+// It is FieldOfView, which is already symmetric, and is kept for callers that want to say so.
+func SymmetricFieldOfView(origin H, radius int, blocks func(H) bool) map[H]bool {
+	return FieldOfView(origin, radius, blocks)
+}
+
+// fovEpsilon absorbs rounding when comparing angles.
+const fovEpsilon = 1e-9
+
+// fovAngle returns the angle of the center of h as seen from origin, in [0, 2π).
+func fovAngle(origin, h H) float64 {
+	ox, oy := straightPoint(origin)
+	x, y := straightPoint(h)
+	angle := math.Atan2(y-oy, x-ox)
+	if angle < 0 {
+		angle += 2 * math.Pi
+	}
+	return angle
+}
+
+// fovShadow returns the angles spanned by the middle of the sides of h as seen from origin.
+// A line only surely passes through h inside them, the corners are left to Visible.
+func fovShadow(origin, h H) shadow {
+	ox, oy := straightPoint(origin)
+	x, y := straightPoint(h)
+	center := fovAngle(origin, h)
+	low, high := 0., 0.
+	for k := 0; k < 6; k++ {
+		// Middle of the sides of a unit hex on the grid used by straightPoint.
+		side := 2. * math.Pi * float64(k) / 6.
+		cx, cy := x+math.Sqrt(3)/2*math.Cos(side), y+math.Sqrt(3)/2*math.Sin(side)
+		delta := math.Atan2(cy-oy, cx-ox) - center
+		for delta > math.Pi {
+			delta -= 2 * math.Pi
+		}
+		for delta < -math.Pi {
+			delta += 2 * math.Pi
+		}
+		low, high = math.Min(low, delta), math.Max(high, delta)
+	}
+	return shadow{center + low, center + high}
+}
+
+// shadow is an arc of angles hidden behind a blocking hex.
+type shadow struct {
+	low, high float64
+}
+
+// shadowList is a sorted set of merged shadows.
+// Every shadow is stored again a full turn earlier and later so arcs crossing zero merge.
+type shadowList []shadow
+
+// add merges a shadow into the list.
+func (l shadowList) add(s shadow) shadowList {
+	l = append(l,
+		shadow{s.low - 2*math.Pi, s.high - 2*math.Pi},
+		s,
+		shadow{s.low + 2*math.Pi, s.high + 2*math.Pi})
+	sort.Slice(l, func(i, j int) bool { return l[i].low < l[j].low })
+
+	merged := l[:1]
+	for _, next := range l[1:] {
+		last := &merged[len(merged)-1]
+		if next.low <= last.high+fovEpsilon {
+			last.high = math.Max(last.high, next.high)
+			continue
+		}
+		merged = append(merged, next)
+	}
+	return merged
+}
+
+// covers reports if an angle in [0, 2π) is strictly inside a shadow.
+func (l shadowList) covers(angle float64) bool {
+	for _, s := range l {
+		if angle > s.low+fovEpsilon && angle < s.high-fovEpsilon {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,145 @@
+package hexagolang
+
+import (
+	"testing"
+)
+
+// I need to know if one hex can see another.
+// Rational, ranged attacks need a clear line of sight.
+func TestVisible(t *testing.T) {
+	walls := map[H]bool{{2, 0}: true, {0, 3}: true}
+	blocks := func(h H) bool { return walls[h] }
+	plan := []struct {
+		a, b    H
+		visible bool
+	}{
+		{H{0, 0}, H{0, 0}, true},
+		{H{0, 0}, H{1, 0}, true},
+		{H{0, 0}, H{2, 0}, true},
+		{H{0, 0}, H{3, 0}, false},
+		{H{4, 0}, H{0, 0}, false},
+		{H{0, 0}, H{0, 5}, false},
+		{H{0, 0}, H{-3, 3}, true},
+		// Grazing the corner between H{2, 0} and H{2, -1} passes H{2, -1}.
+		{H{0, 0}, H{4, -1}, true},
+		{H{4, -1}, H{0, 0}, true},
+	}
+	for tc, expected := range plan {
+		if result := Visible(expected.a, expected.b, blocks); result != expected.visible {
+			t.Errorf("index %d: expected visible %v from %+v to %+v, got %v",
+				tc, expected.visible, expected.a, expected.b, result)
+		}
+	}
+}
+
+// I need to know every hex a unit can see.
+// Rational, fog of war is drawn from the field of view.
+func TestFieldOfView(t *testing.T) {
+	plan := []struct {
+		walls []H
+		rad   int
+		pos   []H
+		neg   []H
+		size  int
+	}{
+		{nil, 3, []H{{0, 0}, {3, 0}, {-3, 3}}, []H{{4, 0}}, 37},
+		{[]H{{1, 0}}, 3,
+			[]H{{1, 0}, {2, -1}, {1, 1}},
+			[]H{{2, 0}, {3, 0}},
+			0,
+		},
+		{[]H{{1, 0}, {1, -1}}, 3,
+			[]H{{1, 0}, {1, -1}, {0, -1}, {1, 1}},
+			[]H{{2, 0}, {2, -1}, {2, -2}, {3, -1}},
+			0,
+		},
+	}
+
+	for tc, params := range plan {
+		walls := map[H]bool{}
+		for _, h := range params.walls {
+			walls[h] = true
+		}
+		result := FieldOfView(H{0, 0}, params.rad, func(h H) bool { return walls[h] })
+		if params.size != 0 && len(result) != params.size {
+			t.Errorf("index %d: Expected %d results, got %d.", tc, params.size, len(result))
+			t.Logf("result was %+v", result)
+		}
+		for k, v := range params.pos {
+			if !result[v] {
+				t.Errorf("index %d-%d: positive result expected for %+v. got false", tc, k, v)
+				t.Logf("result was %+v", result)
+			}
+		}
+		for k, v := range params.neg {
+			if result[v] {
+				t.Errorf("index %d-%d: negative result expected for %+v. Got true", tc, k, v)
+				t.Logf("result was %+v", result)
+			}
+		}
+	}
+}
+
+// I need sight to work both ways.
+// Rational, a unit that can shoot another must be visible to it.
+func TestSymmetricFieldOfView(t *testing.T) {
+	walls := map[H]bool{{1, 0}: true, {-2, 1}: true, {0, -2}: true, {2, 1}: true, {-1, 3}: true}
+	blocks := func(h H) bool { return walls[h] }
+	rad := 4
+	seen := map[H]map[H]bool{}
+	for h := range Range(H{0, 0}, rad) {
+		seen[h] = SymmetricFieldOfView(h, rad, blocks)
+	}
+	for a, fov := range seen {
+		for b := range fov {
+			if other, ok := seen[b]; ok && !other[a] {
+				t.Errorf("%+v sees %+v but not the other way around", a, b)
+			}
+		}
+	}
+	if seen[H{0, 0}][H{2, 0}] {
+		t.Errorf("expected H{2, 0} to be hidden behind H{1, 0}")
+	}
+}
+
+// I need corners between hexagons to block the same way in every direction.
+// Rational, a map and its mirror image should hide the same hexagons.
+func TestFieldOfViewCorners(t *testing.T) {
+	mirror := func(h H) H { return H{h.R, h.Q} }
+	plan := [][]H{
+		{{1, 0}},
+		{{2, 0}},
+		{{1, 0}, {0, 2}},
+		{{2, -1}, {-1, 3}, {0, -2}},
+	}
+	for tc, walls := range plan {
+		blocking, mirrored := map[H]bool{}, map[H]bool{}
+		for _, h := range walls {
+			blocking[h], mirrored[mirror(h)] = true, true
+		}
+		blocks := func(h H) bool { return blocking[h] }
+		mirrorBlocks := func(h H) bool { return mirrored[h] }
+		result := FieldOfView(H{0, 0}, 5, blocks)
+		mirrorResult := FieldOfView(H{0, 0}, 5, mirrorBlocks)
+		for h := range Range(H{0, 0}, 5) {
+			visible := Visible(H{0, 0}, h, blocks)
+			if result[h] != visible {
+				t.Errorf("index %d: expected FieldOfView %v for %+v like Visible, got %v", tc, visible, h, result[h])
+			}
+			if mirrorVisible := Visible(H{0, 0}, mirror(h), mirrorBlocks); mirrorVisible != visible {
+				t.Errorf("index %d: expected mirrored Visible %v for %+v, got %v", tc, visible, mirror(h), mirrorVisible)
+			}
+			if mirrorResult[mirror(h)] != result[h] {
+				t.Errorf("index %d: expected mirrored FieldOfView %v for %+v, got %v", tc, result[h], mirror(h), mirrorResult[mirror(h)])
+			}
+		}
+	}
+	// The line to H{1, 1} grazes H{1, 0} and H{0, 1}, it's only blocked by both.
+	for tc, walls := range [][]H{{{1, 0}}, {{0, 1}}, {{1, 0}, {0, 1}}} {
+		blocks := func(h H) bool { return h == walls[0] || h == walls[len(walls)-1] }
+		expected := len(walls) == 1
+		if result := Visible(H{0, 0}, H{1, 1}, blocks); result != expected {
+			t.Errorf("index %d: expected visible %v through %+v, got %v", tc, expected, walls, result)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package hexagolang
+
+import (
+	"math"
+)
+
+// FH is a point between hexagon centers in fractional cube coordinates, Q + R + S is always 0.
+type FH struct {
+	Q, R, S float64
+}
+
+// Fractional converts the hex to fractional coordinates.
+func (h H) Fractional() FH {
+	return FH{float64(h.Q), float64(h.R), float64(-h.Q - h.R)}
+}
+
+// Round returns the hex containing the fractional point.
+func (f FH) Round() H {
+	return unfloat(f.Q, f.S, f.R)
+}
+
+// Distance returns the manhattan distance between two fractional points.
+func (f FH) Distance(b FH) float64 {
+	return (math.Abs(f.Q-b.Q) + math.Abs(f.R-b.R) + math.Abs(f.S-b.S)) / 2.
+}
+
+// Lerp returns the point t of the way from a to b. (a + (b - a) * t)
+func Lerp(a, b FH, t float64) FH {
+	return FH{
+		Q: a.Q + (b.Q-a.Q)*t,
+		R: a.R + (b.R-a.R)*t,
+		S: a.S + (b.S-a.S)*t,
+	}
+}
+
+// FractionalFor returns the fractional hex under a point.
+func (l Layout) FractionalFor(f F) FH {
+	x, y :=
+		(f.X-l.Origin.X)/l.Radius.X,
+		(f.Y-l.Origin.Y)/l.Radius.Y
+	q := l.m.b[0]*x + l.m.b[1]*y
+	r := l.m.b[2]*x + l.m.b[3]*y
+	return FH{q, r, -q - r}
+}
+
+// CenterForFractional returns the point of a fractional hex based on the layout.
+func (l Layout) CenterForFractional(h FH) F {
+	x := (l.m.f[0]*h.Q + l.m.f[1]*h.R) * l.Radius.X
+	y := (l.m.f[2]*h.Q + l.m.f[3]*h.R) * l.Radius.Y
+	return F{x + l.Origin.X, y + l.Origin.Y}
+}
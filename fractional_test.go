@@ -0,0 +1,66 @@
+package hexagolang
+
+import (
+	"math"
+	"testing"
+)
+
+// I need positions between hex centers.
+// Rational, units animate smoothly from one hex to the next.
+func TestLerp(t *testing.T) {
+	plan := []struct {
+		a, b H
+		t    float64
+		f    FH
+		h    H
+	}{
+		{H{0, 0}, H{2, 0}, 0, FH{0, 0, 0}, H{0, 0}},
+		{H{0, 0}, H{2, 0}, 0.5, FH{1, 0, -1}, H{1, 0}},
+		{H{0, 0}, H{2, 0}, 1, FH{2, 0, -2}, H{2, 0}},
+		{H{-1, 3}, H{3, -1}, 0.25, FH{0, 2, -2}, H{0, 2}},
+		{H{0, 0}, H{1, 0}, 0.4, FH{0.4, 0, -0.4}, H{0, 0}},
+		{H{0, 0}, H{1, 0}, 0.6, FH{0.6, 0, -0.6}, H{1, 0}},
+	}
+	for tc, expected := range plan {
+		result := Lerp(expected.a.Fractional(), expected.b.Fractional(), expected.t)
+		if result.Distance(expected.f) > 0.0001 {
+			t.Errorf("index %d: expected %+v, got %+v", tc, expected.f, result)
+		}
+		if math.Abs(result.Q+result.R+result.S) > 0.0001 {
+			t.Errorf("index %d: %+v isn't a valid cube coordinate", tc, result)
+		}
+		if h := result.Round(); h != expected.h {
+			t.Errorf("index %d: expected %+v to round to %+v, got %+v", tc, result, expected.h, h)
+		}
+	}
+
+	if d := (H{2, 1}).Fractional().Distance(H{10, 0}.Fractional()); d != 8 {
+		t.Errorf("expected a distance of 8, got %f", d)
+	}
+}
+
+// I need to translate between screen coordinates and fractional hex coordinates.
+// Rational, hit testing inside a hex needs the position within the hex.
+func TestFractionalScreenConversion(t *testing.T) {
+	layouts := []Layout{
+		MakeLayout(F{10, 10}, F{0, 0}, OrientationFlat),
+		MakeLayout(F{12, 8}, F{5, 5}, OrientationFlat),
+		MakeLayout(F{6, 14}, F{-3, 9}, OrientationPointy),
+	}
+	for tc, layout := range layouts {
+		for h := range Range(H{0, 0}, 4) {
+			center := layout.CenterFor(h)
+			if result := layout.HexFor(center); result != h {
+				t.Errorf("index %d: hex for %+v expected %+v, got %+v", tc, center, h, result)
+			}
+			mid := Lerp(h.Fractional(), h.Neighbor(DirectionPosR).Fractional(), 0.3)
+			f := layout.CenterForFractional(mid)
+			if result := layout.FractionalFor(f); result.Distance(mid) > 0.0001 {
+				t.Errorf("index %d: fractional for %+v expected %+v, got %+v", tc, f, mid, result)
+			}
+			if result := layout.HexFor(f); result != h {
+				t.Errorf("index %d: hex for %+v expected %+v, got %+v", tc, f, h, result)
+			}
+		}
+	}
+}
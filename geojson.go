@@ -0,0 +1,110 @@
+package hexagolang
+
+// GeoJSON as described in
+// https://datatracker.ietf.org/doc/html/rfc7946
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// GeoJSONOptions controls what WriteGeoJSON exports.
+type GeoJSONOptions struct {
+	Properties func(H) map[string]interface{} // Properties returns extra properties of a hex, added to its "q" and "r".
+	Outline    bool                           // Outline adds a feature with the outline of the hexagons merged together.
+	Project    func(F) [2]float64             // Project maps layout units to longitude and latitude, as-is when nil.
+}
+
+// WriteGeoJSON writes the hexagons as a GeoJSON FeatureCollection with a polygon per hex.
+// Positions are longitude then latitude in degrees, so either the layout is in degrees with
+// X as longitude and Y as latitude, or Project converts to them.
+// Outer rings run counter clockwise and holes clockwise.
+func (l Layout) WriteGeoJSON(w io.Writer, hexes map[H]bool, opts GeoJSONOptions) error {
+	region := make(Region, len(hexes))
+	for h, in := range hexes {
+		if in {
+			region[h] = true
+		}
+	}
+
+	out := geoCollection{Type: "FeatureCollection", Features: []geoFeature{}}
+	for _, h := range region.Hexes() {
+		corners := l.corners(h)
+		properties := map[string]interface{}{}
+		if opts.Properties != nil {
+			for k, v := range opts.Properties(h) {
+				properties[k] = v
+			}
+		}
+		properties["q"], properties["r"] = h.Q, h.R
+		out.Features = append(out.Features, geoFeature{
+			Type:       "Feature",
+			Geometry:   geoGeometry{"Polygon", [][][2]float64{geoRing(corners[:], true, opts.Project)}},
+			Properties: properties,
+		})
+	}
+
+	if opts.Outline && len(region) > 0 {
+		var polygons [][][][2]float64
+		for _, group := range Components(region) {
+			var polygon [][][2]float64
+			for _, loop := range l.Outline(group) {
+				outer := signedArea(loop) < 0
+				ring := geoRing(loop, outer, opts.Project)
+				if outer {
+					// The outer boundary goes first, followed by the holes.
+					polygon = append([][][2]float64{ring}, polygon...)
+				} else {
+					polygon = append(polygon, ring)
+				}
+			}
+			polygons = append(polygons, polygon)
+		}
+		out.Features = append(out.Features, geoFeature{
+			Type:       "Feature",
+			Geometry:   geoGeometry{"MultiPolygon", polygons},
+			Properties: map[string]interface{}{"outline": true},
+		})
+	}
+
+	return json.NewEncoder(w).Encode(out)
+}
+
+// geoRing returns a closed ring of positions, counter clockwise when outer and clockwise otherwise.
+func geoRing(points []F, outer bool, project func(F) [2]float64) [][2]float64 {
+	result := make([][2]float64, 0, len(points)+1)
+	area := 0.
+	for k, p := range points {
+		position := [2]float64{p.X, p.Y}
+		if project != nil {
+			position = project(p)
+		}
+		result = append(result, position)
+		if k > 0 {
+			area += result[k-1][0]*position[1] - position[0]*result[k-1][1]
+		}
+	}
+	area += result[len(result)-1][0]*result[0][1] - result[0][0]*result[len(result)-1][1]
+	if (area > 0) != outer {
+		for i, j := 0, len(result)-1; i < j; i, j = i+1, j-1 {
+			result[i], result[j] = result[j], result[i]
+		}
+	}
+	return append(result, result[0])
+}
+
+type geoCollection struct {
+	Type     string       `json:"type"`
+	Features []geoFeature `json:"features"`
+}
+
+type geoFeature struct {
+	Type       string                 `json:"type"`
+	Geometry   geoGeometry            `json:"geometry"`
+	Properties map[string]interface{} `json:"properties"`
+}
+
+type geoGeometry struct {
+	Type        string      `json:"type"`
+	Coordinates interface{} `json:"coordinates"`
+}
@@ -0,0 +1,135 @@
+package hexagolang
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"testing"
+)
+
+// I need to load hexagons into GIS tools.
+// Rational, analytics per hex are overlaid on maps in QGIS.
+func TestWriteGeoJSON(t *testing.T) {
+	hexes := Region(Ring(H{0, 0}, 1)).Union(MakeRegion(H{5, 0}))
+	hexes[H{9, 9}] = false
+	sanFrancisco := F{-122.4, 37.7}
+
+	plan := []struct {
+		name    string
+		layout  Layout
+		project func(F) [2]float64
+		size    float64 // size of a hex in degrees
+	}{
+		{"degrees", MakeLayout(F{0.01, 0.01}, sanFrancisco, OrientationFlat), nil, 0.01},
+		// Pixels grow down the screen while latitude grows north.
+		{"projected", MakeLayout(F{10, 10}, F{100, 100}, OrientationPointy), func(f F) [2]float64 {
+			return [2]float64{sanFrancisco.X + (f.X-100)*0.001, sanFrancisco.Y - (f.Y-100)*0.001}
+		}, 0.01},
+	}
+	for _, params := range plan {
+		var buf bytes.Buffer
+		err := params.layout.WriteGeoJSON(&buf, hexes, GeoJSONOptions{
+			Properties: func(h H) map[string]interface{} {
+				return map[string]interface{}{"population": h.Q * 10}
+			},
+			Outline: true,
+			Project: params.project,
+		})
+		if err != nil {
+			t.Fatalf("%s: write: %v", params.name, err)
+		}
+
+		var out struct {
+			Type     string
+			Features []struct {
+				Type     string
+				Geometry struct {
+					Type        string
+					Coordinates json.RawMessage
+				}
+				Properties map[string]interface{}
+			}
+		}
+		if err := json.Unmarshal(buf.Bytes(), &out); err != nil {
+			t.Fatalf("%s: read: %v", params.name, err)
+		}
+		if out.Type != "FeatureCollection" || len(out.Features) != 8 {
+			t.Fatalf("%s: expected a collection of 8 features, got %s with %d", params.name, out.Type, len(out.Features))
+		}
+
+		// area is positive for rings running counter clockwise with latitude growing north.
+		area := func(ring [][2]float64) float64 {
+			result := 0.
+			for k := 1; k < len(ring); k++ {
+				result += ring[k-1][0]*ring[k][1] - ring[k][0]*ring[k-1][1]
+			}
+			return result
+		}
+		checkPolygon := func(name string, polygon [][][2]float64) {
+			for k, ring := range polygon {
+				if len(ring) < 4 || ring[0] != ring[len(ring)-1] {
+					t.Errorf("%s: ring %d isn't closed: %v", name, k, ring)
+				}
+				if k == 0 && area(ring) <= 0 {
+					t.Errorf("%s: the outer ring should run counter clockwise", name)
+				}
+				if k > 0 && area(ring) >= 0 {
+					t.Errorf("%s: hole %d should run clockwise", name, k)
+				}
+				for _, p := range ring {
+					// Everything lies within a few hexagons of San Francisco.
+					if math.Abs(p[0]-sanFrancisco.X) > 20*params.size || math.Abs(p[1]-sanFrancisco.Y) > 20*params.size {
+						t.Errorf("%s: %v is nowhere near San Francisco", name, p)
+					}
+				}
+			}
+		}
+
+		for k, feature := range out.Features[:7] {
+			var polygon [][][2]float64
+			if err := json.Unmarshal(feature.Geometry.Coordinates, &polygon); err != nil || feature.Geometry.Type != "Polygon" {
+				t.Fatalf("%s: index %d: expected a polygon, got %s %v", params.name, k, feature.Geometry.Type, err)
+			}
+			if len(polygon) != 1 || len(polygon[0]) != 7 {
+				t.Errorf("%s: index %d: expected one ring of 7 points, got %v", params.name, k, polygon)
+			}
+			checkPolygon(params.name, polygon)
+			q, r := feature.Properties["q"].(float64), feature.Properties["r"].(float64)
+			if !hexes[H{int(q), int(r)}] || feature.Properties["population"] != q*10 {
+				t.Errorf("%s: index %d: wrong properties %v", params.name, k, feature.Properties)
+			}
+			center := params.layout.CenterFor(H{int(q), int(r)})
+			position := [2]float64{center.X, center.Y}
+			if params.project != nil {
+				position = params.project(center)
+			}
+			for _, p := range polygon[0] {
+				if d := math.Hypot(p[0]-position[0], p[1]-position[1]); math.Abs(d-params.size) > 1e-9 {
+					t.Errorf("%s: index %d: %v is %v from the center of the hex", params.name, k, p, d)
+				}
+			}
+		}
+
+		outline := out.Features[7]
+		var polygons [][][][2]float64
+		if err := json.Unmarshal(outline.Geometry.Coordinates, &polygons); err != nil || outline.Geometry.Type != "MultiPolygon" {
+			t.Fatalf("%s: outline: expected a multi polygon, got %s %v", params.name, outline.Geometry.Type, err)
+		}
+		if len(polygons) != 2 || len(polygons[0])+len(polygons[1]) != 3 {
+			t.Errorf("%s: outline: expected a ring with a hole and a single hex, got %v", params.name, polygons)
+		}
+		for _, polygon := range polygons {
+			checkPolygon(params.name+" outline", polygon)
+		}
+		if outline.Properties["outline"] != true {
+			t.Errorf("%s: outline: wrong properties %v", params.name, outline.Properties)
+		}
+	}
+
+	var buf bytes.Buffer
+	l := MakeLayout(F{0.01, 0.01}, sanFrancisco, OrientationFlat)
+	if err := l.WriteGeoJSON(&buf, nil, GeoJSONOptions{Outline: true}); err != nil ||
+		buf.String() != `{"type":"FeatureCollection","features":[]}`+"\n" {
+		t.Errorf("empty: got %s %v", buf.String(), err)
+	}
+}
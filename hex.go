@@ -85,6 +85,25 @@ func RotateCounterClockwise(origin, moving H) H {
 	return Add(origin, after)
 }
 
+// Rotate rotates one point around another point by steps of 60 degrees.
+// Positive steps rotate counter clockwise and negative steps clockwise.
+func Rotate(origin, moving H, steps int) H {
+	return Add(origin, RotateDelta(Subtract(moving, origin), steps))
+}
+
+// RotateDelta rotates a delta by steps of 60 degrees, counter clockwise when positive.
+func RotateDelta(d D, steps int) D {
+	steps = (steps%6 + 6) % 6
+	if steps >= 3 {
+		d = D{-d.Q, -d.R, -d.S}
+		steps -= 3
+	}
+	for ; steps > 0; steps-- {
+		d = D{-d.S, -d.Q, -d.R}
+	}
+	return d
+}
+
 // Length returns the manhattan distance for a delta
 func Length(d D) int {
 	abs := d.Abs()
@@ -146,6 +165,19 @@ const (
 	DirectionUndefined
 )
 
+// Rotate returns the direction steps sides along, counter clockwise when positive.
+func (d DirectionEnum) Rotate(steps int) DirectionEnum {
+	if d < DirectionPosQ || d >= DirectionUndefined {
+		return DirectionUndefined
+	}
+	return DirectionEnum((int(d) + steps%6 + 6) % 6)
+}
+
+// Opposite returns the direction pointing the other way.
+func (d DirectionEnum) Opposite() DirectionEnum {
+	return d.Rotate(3)
+}
+
 var neighbors = []D{
 	{1, 0, -1}, {1, -1, 0}, {0, -1, 1}, // positive
 	{-1, 0, 1}, {-1, 1, 0}, {0, 1, -1}, // negative
@@ -191,51 +223,148 @@ const (
 	DiagonalUndefined
 )
 
+// Rotate returns the diagonal steps corners along, counter clockwise when positive.
+func (d Diagonal) Rotate(steps int) Diagonal {
+	if d < DiagonalPosQ || d >= DiagonalUndefined {
+		return DiagonalUndefined
+	}
+	return Diagonal((int(d) + steps%6 + 6) % 6)
+}
+
+// Opposite returns the diagonal pointing the other way.
+func (d Diagonal) Opposite() Diagonal {
+	return d.Rotate(3)
+}
+
 var diagonals = []D{
 	{2, -1, -1}, {1, -2, 1}, {-1, -1, 2}, // positive
 	{-2, 1, 1}, {-1, 2, -1}, {1, 1, -2}, // negative
 	{}, // undefined
 }
 
-// DiagonalDelta returns the delta required to move a single hex in a direction.
-func DiagonalDelta(d DirectionEnum) D {
+// DiagonalDelta returns the delta required to move past a single point of a hex.
+func DiagonalDelta(d Diagonal) D {
 	return diagonals[d]
 }
 
+// DiagonalNeighbor one step past a specific point.
+func (h H) DiagonalNeighbor(d Diagonal) H {
+	return Add(h, DiagonalDelta(d))
+}
+
+// DiagonalOf returns the Diagonal one point is in comparison to another point.
+// A delta of zero has no diagonal.
+func DiagonalOf(d D) Diagonal {
+	if d == (D{}) {
+		return DiagonalUndefined
+	}
+	abs := d.Abs()
+	if abs.Q >= abs.R && abs.Q >= abs.S {
+		if d.Q < 0 {
+			return DiagonalNegQ
+		}
+		return DiagonalPosQ
+	}
+	if abs.R >= abs.S {
+		if d.R < 0 {
+			return DiagonalNegR
+		}
+		return DiagonalPosR
+	}
+	if d.S < 0 {
+		return DiagonalNegS
+	}
+	return DiagonalPosS
+}
+
+// NeighborsWithDiagonals returns the six neighbors and six diagonal neighbors of h.
+// They are ordered counter clockwise from DirectionPosQ, each diagonal following the sides it sits between.
+func (h H) NeighborsWithDiagonals() []H {
+	results := make([]H, 0, 12)
+	for d := DirectionPosQ; d < DirectionUndefined; d++ {
+		results = append(results, h.Neighbor(d), h.DiagonalNeighbor(Diagonal(d)))
+	}
+	return results
+}
+
+// DiagonalRing returns the hex points reached in exactly rad diagonal steps and no fewer.
+func DiagonalRing(h H, rad int) map[H]bool {
+	results := make(map[H]bool, 6*rad)
+	if rad < 1 {
+		return results
+	}
+	// The diagonals form a grid of their own, walk its ring the same way as RingWalk.
+	h = Add(h, Multiply(DiagonalDelta(DiagonalPosR), rad))
+	for i := 0; i < 6; i++ {
+		side := DiagonalPosR.Rotate(2 + i)
+		for j := 0; j < rad; j++ {
+			results[h] = true
+			h = h.DiagonalNeighbor(side)
+		}
+	}
+	return results
+}
+
 // Line gets the hexagons on a line between two hex.
 func Line(a, b H) []H {
+	results := make([]H, 0, Length(Subtract(a, b))+2)
+	EachInLine(a, b, func(h H) bool {
+		results = append(results, h)
+		return true
+	})
+	return results
+}
+
+// EachInLine calls fn for each hexagon of Line in order without allocating.
+// The walk stops early when fn returns false.
+func EachInLine(a, b H, fn func(H) bool) {
 	delta := Subtract(a, b)
 	n := Length(delta)
+	if n == 0 {
+		fn(a)
+		return
+	}
 	dir := Direction(delta)
 
-	results := make([]H, 0, n)
-	visited := make(map[H]bool, n)
-	ax, ay, az := a.Float()
-	bx, by, bz := b.Float()
-	x, y, z := bx-ax, by-ay, bz-az
+	// A rounded point can only land on the hexagons just before it, so
+	// remembering the last two replaces a visited set.
+	var last, before H
+	visited := func(h H, count int) bool {
+		return (count > 0 && h == last) || (count > 1 && h == before)
+	}
+	fa, fb := a.Fractional(), b.Fractional()
 
 	step := 1. / float64(n)
 	for h := 0; h <= n; h++ {
-		t := step * float64(h)
-		pnt := unfloat(ax+x*t, ay+y*t, az+z*t)
-		for visited[pnt] {
+		pnt := Lerp(fa, fb, step*float64(h)).Round()
+		for visited(pnt, h) {
 			pnt = pnt.Neighbor(dir)
 		}
-		results = append(results, pnt)
-		visited[pnt] = true
+		if !fn(pnt) {
+			return
+		}
+		before, last = last, pnt
 	}
-	if !visited[b] {
-		results = append(results, b)
+	if !visited(b, n+1) {
+		fn(b)
 	}
-
-	return results
 }
 
 // Range returns the slice of all points in a distance from a point.
 func Range(h H, rad int) map[H]bool {
 	results := make(map[H]bool, rad*rad)
+	EachInRange(h, rad, func(k H) bool {
+		results[k] = true
+		return true
+	})
+	return results
+}
+
+// EachInRange calls fn for each hexagon of Range without allocating.
+// The walk stops early when fn returns false.
+func EachInRange(h H, rad int, fn func(H) bool) {
 	if rad < 1 {
-		return results
+		return
 	}
 	for x := -rad; x <= rad; x++ {
 		for y := intMax(-rad, -x-rad); y <= intMin(rad, -x+rad); y++ {
@@ -245,28 +374,99 @@ func Range(h H, rad int) map[H]bool {
 				R: int(z),
 				S: int(y),
 			}
-			results[Add(h, delta)] = true
+			if !fn(Add(h, delta)) {
+				return
+			}
 		}
 	}
-	return results
 }
 
 // Ring returns the ring of hex points specific manhattan distance from h.
 func Ring(h H, rad int) map[H]bool {
 	results := make(map[H]bool)
+	EachInRing(h, rad, func(k H) bool {
+		results[k] = true
+		return true
+	})
+	return results
+}
+
+// EachInRing calls fn for each hexagon of Ring without allocating.
+// The walk stops early when fn returns false.
+func EachInRing(h H, rad int, fn func(H) bool) {
 	if rad < 1 {
-		return results
+		return
 	}
 
-	h = Add(h, Multiply(NeighborDelta(DirectionPosS), rad))
-	results[h] = true
-	if rad > 1 {
-		for i := 0; i < 6; i++ {
-			for j := 0; j < rad; j++ {
-				h = Add(h, NeighborDelta(DirectionEnum(i)))
-				results[h] = true
+	h = Add(h, Multiply(NeighborDelta(DirectionPosR), rad))
+	for i := 0; i < 6; i++ {
+		for j := 0; j < rad; j++ {
+			if !fn(h) {
+				return
 			}
+			h = Add(h, NeighborDelta(DirectionEnum(i)))
+		}
+	}
+}
+
+// WindingEnum is the order hexagons are visited around a ring, as seen on the screen.
+type WindingEnum int
+
+// String returns the string name of the winding.
+func (w WindingEnum) String() string {
+	ret := "WindingUndefined"
+	switch w {
+	case WindingCounterClockwise:
+		ret = "WindingCounterClockwise"
+	case WindingClockwise:
+		ret = "WindingClockwise"
+	}
+	return ret
+}
+
+// Constants for the ring windings.
+const (
+	WindingCounterClockwise WindingEnum = iota
+	WindingClockwise
+	WindingUndefined
+)
+
+// RingWalk returns the ring of hex points specific manhattan distance from h in order.
+// The walk begins at the corner in the start direction and winds around h.
+// An undefined start or winding returns no hexagons.
+func RingWalk(h H, rad int, start DirectionEnum, w WindingEnum) []H {
+	if rad < 1 || start < DirectionPosQ || start >= DirectionUndefined ||
+		(w != WindingCounterClockwise && w != WindingClockwise) {
+		return []H{}
+	}
+	results := make([]H, 0, 6*rad)
+
+	h = Add(h, Multiply(NeighborDelta(start), rad))
+	for i := 0; i < 6; i++ {
+		// Each side runs parallel to the corner two directions along.
+		side := start.Rotate(2 + i)
+		if w == WindingClockwise {
+			side = start.Rotate(-2 - i)
 		}
+		for j := 0; j < rad; j++ {
+			results = append(results, h)
+			h = Add(h, NeighborDelta(side))
+		}
+	}
+	return results
+}
+
+// Spiral returns every hex within rad of h, starting with h and then ring by ring.
+// Each ring is walked counter clockwise from the DirectionPosR corner.
+// Unlike Range, a radius of 0 returns h.
+func Spiral(h H, rad int) []H {
+	if rad < 0 {
+		return []H{}
+	}
+	results := make([]H, 0, 3*rad*(rad+1)+1)
+	results = append(results, h)
+	for k := 1; k <= rad; k++ {
+		results = append(results, RingWalk(h, k, DirectionPosR, WindingCounterClockwise)...)
 	}
 	return results
 }
@@ -289,6 +489,13 @@ func unfloat(x, y, z float64) H {
 	}
 }
 
+func intAbs(a int) int {
+	if a < 0 {
+		return -a
+	}
+	return a
+}
+
 func intMax(a, b int) int {
 	if a < b {
 		return b
@@ -438,20 +645,25 @@ func (l Layout) CenterFor(h H) F {
 
 // HexFor for a hex.F that represents a point where things are laid out.
 func (l Layout) HexFor(f F) H {
-	x, y :=
-		f.X-l.Origin.X,
-		f.Y-l.Origin.Y
-	q := (l.m.b[0]*x + l.m.b[1]*y) / l.Radius.X
-	r := (l.m.b[2]*x + l.m.b[3]*y) / l.Radius.Y
-	return unfloat(q, -q-r, r)
+	return l.FractionalFor(f).Round()
 }
 
 // RingFor returns a set of hex within rad pixel distance of center.
 func (l Layout) RingFor(center H, rad float64) map[H]bool {
 	result := make(map[H]bool, 1)
+	l.eachOnCircle(center, rad, func(h H) bool {
+		result[h] = true
+		return true
+	})
+	return result
+}
+
+// eachOnCircle calls fn with the hex under each point of the screen circle, hexagons repeat.
+// The walk stops early when fn returns false.
+func (l Layout) eachOnCircle(center H, rad float64, fn func(H) bool) {
 	if rad < l.Radius.X && rad < l.Radius.Y {
-		result[center] = true
-		return result
+		fn(center)
+		return
 	}
 	cp := l.CenterFor(center)
 	P := 1 - rad
@@ -468,7 +680,7 @@ func (l Layout) RingFor(center H, rad float64) map[H]bool {
 			break
 		}
 
-		points := []F{
+		points := [8]F{
 			{pxl.X + cp.X, pxl.Y + cp.Y},
 			{-pxl.X + cp.X, pxl.Y + cp.Y},
 			{pxl.X + cp.X, -pxl.Y + cp.Y},
@@ -479,30 +691,71 @@ func (l Layout) RingFor(center H, rad float64) map[H]bool {
 			{-pxl.Y + cp.X, -pxl.X + cp.Y},
 		}
 		for _, v := range points {
-			result[l.HexFor(v)] = true
+			if !fn(l.HexFor(v)) {
+				return
+			}
 		}
 	}
-	return result
 }
 
 // AreaFor returns all hex in the area of a screen circle.
 func (l Layout) AreaFor(center H, rad float64) map[H]bool {
-	loop := l.RingFor(center, rad)
 	result := make(map[H]bool)
-	for k, v := range loop {
-		if v == true {
-			result[k] = true
-			for _, inside := range Line(k, center) {
-				result[inside] = true
-			}
+	l.eachInArea(center, rad, result, func(H) bool { return true })
+	return result
+}
+
+// EachInArea calls fn once for each hex of AreaFor, in the order they are reached from the circle.
+// The hexagons already reached are kept in scratch, which is emptied first; a
+// nil scratch allocates one. Reusing the same scratch between calls makes the
+// walk allocation free once the set has grown to the size of the area.
+// The walk stops early when fn returns false.
+func (l Layout) EachInArea(center H, rad float64, scratch map[H]bool, fn func(H) bool) {
+	if scratch == nil {
+		scratch = make(map[H]bool)
+	}
+	for h := range scratch {
+		delete(scratch, h)
+	}
+	l.eachInArea(center, rad, scratch, fn)
+}
+
+// eachInArea calls fn the first time each hex of AreaFor is reached, recording them in seen.
+// AreaFor fills the lines from every hex on the circle back to the center.
+func (l Layout) eachInArea(center H, rad float64, seen map[H]bool, fn func(H) bool) {
+	done := false
+	visit := func(h H) bool {
+		if !seen[h] {
+			seen[h] = true
+			done = !fn(h)
 		}
+		return !done
+	}
+	l.eachOnCircle(center, rad, func(k H) bool {
+		if visit(k) {
+			EachInLine(k, center, visit)
+		}
+		return !done
+	})
+}
+
+// nearest returns the pixel distance from a point to the outline of a hex.
+func (l Layout) nearest(h H, f F) float64 {
+	corners := l.corners(h)
+	result := math.Inf(1)
+	for k := range corners {
+		a, b := corners[k], corners[(k+1)%len(corners)]
+		ab, af := b.Subtract(a), f.Subtract(a)
+		t := (af.X*ab.X + af.Y*ab.Y) / (ab.X*ab.X + ab.Y*ab.Y)
+		t = math.Max(0, math.Min(1, t))
+		result = math.Min(result, math.Hypot(af.X-ab.X*t, af.Y-ab.Y*t))
 	}
 	return result
 }
 
-// Vertices returns the location of all verticies for a given hexagon.
-func (l Layout) Vertices(h H) []F {
-	result := make([]F, 6, 7)
+// corners returns the vertices of a hex without the center.
+func (l Layout) corners(h H) [6]F {
+	var result [6]F
 	center := l.CenterFor(h)
 	for k := range result {
 		result[k] = F{
@@ -510,6 +763,14 @@ func (l Layout) Vertices(h H) []F {
 			Y: center.Y + float64(l.Radius.Y)*l.m.s[k],
 		}
 	}
-	result = append(result, center)
+	return result
+}
+
+// Vertices returns the location of all verticies for a given hexagon.
+func (l Layout) Vertices(h H) []F {
+	corners := l.corners(h)
+	result := make([]F, 0, 7)
+	result = append(result, corners[:]...)
+	result = append(result, l.CenterFor(h))
 	return result
 }
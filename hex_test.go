@@ -1,6 +1,8 @@
 package hexagolang
 
 import (
+	"fmt"
+	"runtime"
 	"testing"
 )
 
@@ -155,6 +157,123 @@ func TestDistance(t *testing.T) {
 	}
 }
 
+// I need to rotate hexagons and directions by any number of steps.
+// Rational, unit facing must turn along with the prefab it stands on.
+func TestRotate(t *testing.T) {
+	origin := H{1, -2}
+	moving := H{4, -1}
+	plan := []struct {
+		steps    int
+		expected H
+		dir      DirectionEnum
+		diag     Diagonal
+	}{
+		{0, moving, DirectionPosS, DiagonalNegQ},
+		{1, RotateCounterClockwise(origin, moving), DirectionNegQ, DiagonalPosR},
+		{2, H{2, -6}, DirectionPosR, DiagonalNegS},
+		{3, H{-2, -3}, DirectionNegS, DiagonalPosQ},
+		{-1, RotateClockwise(origin, moving), DirectionNegR, DiagonalPosS},
+		{-3, H{-2, -3}, DirectionNegS, DiagonalPosQ},
+		{7, RotateCounterClockwise(origin, moving), DirectionNegQ, DiagonalPosR},
+		{-13, RotateClockwise(origin, moving), DirectionNegR, DiagonalPosS},
+	}
+	for k, expected := range plan {
+		if result := Rotate(origin, moving, expected.steps); result != expected.expected {
+			t.Errorf("index %d: expected %+v, got %+v", k, expected.expected, result)
+		}
+		if result := DirectionPosS.Rotate(expected.steps); result != expected.dir {
+			t.Errorf("index %d: expected direction %s, got %s", k, expected.dir, result)
+		}
+		if result := DiagonalNegQ.Rotate(expected.steps); result != expected.diag {
+			t.Errorf("index %d: expected diagonal %s, got %s", k, expected.diag, result)
+		}
+		// Rotating the facing and the hex together keeps the facing pointed at the same neighbor.
+		turned := Rotate(origin, moving.Neighbor(DirectionPosS), expected.steps)
+		if result := Rotate(origin, moving, expected.steps).Neighbor(DirectionPosS.Rotate(expected.steps)); result != turned {
+			t.Errorf("index %d: expected neighbor %+v, got %+v", k, turned, result)
+		}
+	}
+
+	for d := DirectionPosQ; d < DirectionUndefined; d++ {
+		if NeighborDelta(d.Opposite()) != Multiply(NeighborDelta(d), -1) {
+			t.Errorf("%s: wrong opposite %s", d, d.Opposite())
+		}
+		if opposite := Diagonal(d).Opposite(); DiagonalDelta(opposite) != Multiply(DiagonalDelta(Diagonal(d)), -1) {
+			t.Errorf("%s: wrong opposite %s", Diagonal(d), opposite)
+		}
+	}
+	if DirectionUndefined.Rotate(1) != DirectionUndefined || DiagonalUndefined.Opposite() != DiagonalUndefined {
+		t.Errorf("undefined should stay undefined")
+	}
+}
+
+// I need to move along the diagonals of a hex.
+// Rational, bishops in hex chess only ever move past the points of a hex.
+func TestDiagonals(t *testing.T) {
+	plan := []struct {
+		d    D
+		diag Diagonal
+	}{
+		{D{2, -1, -1}, DiagonalPosQ},
+		{D{-4, 2, 2}, DiagonalNegQ},
+		{D{1, -2, 1}, DiagonalNegR},
+		{D{-3, 6, -3}, DiagonalPosR},
+		{D{-1, -1, 2}, DiagonalPosS},
+		{D{1, 1, -2}, DiagonalNegS},
+		{D{1, 0, -1}, DiagonalPosQ},
+		{D{}, DiagonalUndefined},
+	}
+	for k, expected := range plan {
+		if result := DiagonalOf(expected.d); result != expected.diag {
+			t.Errorf("index %d: expected %s, got %s", k, expected.diag, result)
+		}
+	}
+
+	h := H{2, -3}
+	around := h.NeighborsWithDiagonals()
+	if len(around) != 12 {
+		t.Fatalf("expected 12 neighbors, got %d", len(around))
+	}
+	for k, n := range around {
+		if k%2 == 0 && n != h.Neighbor(DirectionEnum(k/2)) {
+			t.Errorf("index %d: expected neighbor %+v, got %+v", k, h.Neighbor(DirectionEnum(k/2)), n)
+		}
+		if k%2 == 1 && DiagonalOf(Subtract(n, h)) != Diagonal(k/2) {
+			t.Errorf("index %d: expected %s, got %s", k, Diagonal(k/2), DiagonalOf(Subtract(n, h)))
+		}
+	}
+
+	// Walk the diagonals outward to find how many steps each hex takes.
+	steps := map[H]int{h: 0}
+	frontier := []H{h}
+	for rad := 1; rad <= 3; rad++ {
+		var next []H
+		for _, f := range frontier {
+			for d := DiagonalPosQ; d < DiagonalUndefined; d++ {
+				n := f.DiagonalNeighbor(d)
+				if _, seen := steps[n]; !seen {
+					steps[n] = rad
+					next = append(next, n)
+				}
+			}
+		}
+		frontier = next
+
+		ring := DiagonalRing(h, rad)
+		if len(ring) != 6*rad || len(ring) != len(frontier) {
+			t.Errorf("radius %d: expected %d hexagons, got %d", rad, len(frontier), len(ring))
+		}
+		for n := range ring {
+			if steps[n] != rad {
+				t.Errorf("radius %d: %+v is %d steps away", rad, n, steps[n])
+			}
+		}
+	}
+	if len(DiagonalRing(h, 0)) != 0 {
+		t.Errorf("radius 0 should be empty")
+	}
+}
+
 // I need to draw a line between two hex.
 // Rational, needed in path planning, and drawing screen circles.
 func TestLine(t *testing.T) {
@@ -188,6 +307,8 @@ func TestLine(t *testing.T) {
 			[]H{
 				{9, 5}, {10, 5}, {10, 6}, {11, 6}, {11, 7}, {12, 7}, {12, 8},
 				{13, 8}, {13, 9}, {14, 9}, {14, 10}, {15, 10}, {15, 11}}},
+		{H{7, -3}, H{7, -3},
+			[]H{{7, -3}}},
 	}
 
 	for tc, expected := range plan {
@@ -247,6 +368,105 @@ func TestArea(t *testing.T) {
 	}
 }
 
+// I need to know the set of hex exactly a hex distance from a given hex.
+// Rational, needed for splash damage and field of view.
+func TestRing(t *testing.T) {
+	plan := []struct {
+		a    H
+		rad  int
+		size int
+	}{
+		{H{0, 0}, 0, 0},
+		{H{0, 0}, 1, 6},
+		{H{3, -2}, 2, 12},
+		{H{-5, 7}, 5, 30},
+	}
+
+	for tc, params := range plan {
+		result := Ring(params.a, params.rad)
+		if len(result) != params.size {
+			t.Errorf("index %d: Expected %d results, got %d.", tc, params.size, len(result))
+			t.Logf("result was %+v", result)
+		}
+		for k := range result {
+			if dist := Length(Subtract(k, params.a)); dist != params.rad {
+				t.Errorf("index %d: %+v is %d away, expected %d", tc, k, dist, params.rad)
+			}
+		}
+	}
+}
+
+// I need to visit the hex of a ring in the same order every time.
+// Rational, renderers and simulations must be deterministic.
+func TestRingWalk(t *testing.T) {
+	plan := []struct {
+		a     H
+		rad   int
+		start DirectionEnum
+		w     WindingEnum
+		walk  []H
+	}{
+		{H{0, 0}, 0, DirectionPosQ, WindingClockwise, []H{}},
+		{H{0, 0}, 1, DirectionPosQ, WindingCounterClockwise,
+			[]H{{1, 0}, {1, -1}, {0, -1}, {-1, 0}, {-1, 1}, {0, 1}}},
+		{H{0, 0}, 1, DirectionPosQ, WindingClockwise,
+			[]H{{1, 0}, {0, 1}, {-1, 1}, {-1, 0}, {0, -1}, {1, -1}}},
+		{H{2, 3}, 2, DirectionNegS, WindingCounterClockwise,
+			[]H{
+				{2, 5}, {3, 4}, {4, 3}, {4, 2}, {4, 1}, {3, 1},
+				{2, 1}, {1, 2}, {0, 3}, {0, 4}, {0, 5}, {1, 5}}},
+	}
+
+	for tc, expected := range plan {
+		result := RingWalk(expected.a, expected.rad, expected.start, expected.w)
+		if len(result) != len(expected.walk) {
+			t.Errorf("Index %d: Expected %d steps, got %d", tc, len(expected.walk), len(result))
+			t.Logf("\tResults were %#v", result)
+		}
+		for k := 0; k < intMin(len(expected.walk), len(result)); k++ {
+			if expected.walk[k] != result[k] {
+				t.Errorf("Index %d-%d: Expected %+v, got %+v", tc, k, expected.walk[k], result[k])
+			}
+		}
+	}
+
+	for rad := 1; rad < 5; rad++ {
+		ring := Ring(H{1, 1}, rad)
+		for _, h := range RingWalk(H{1, 1}, rad, DirectionNegR, WindingClockwise) {
+			if !ring[h] {
+				t.Errorf("radius %d: %+v isn't on the ring", rad, h)
+			}
+		}
+	}
+
+	for _, walk := range [][]H{
+		RingWalk(H{1, 1}, 2, DirectionUndefined, WindingClockwise),
+		RingWalk(H{1, 1}, 2, DirectionPosQ, WindingUndefined),
+		RingWalk(H{1, 1}, 2, DirectionEnum(-1), WindingCounterClockwise),
+	} {
+		if len(walk) != 0 {
+			t.Errorf("expected no hexagons for an undefined start or winding, got %+v", walk)
+		}
+	}
+	if spiral := Spiral(H{1, 1}, 0); len(spiral) != 1 || spiral[0] != (H{1, 1}) {
+		t.Errorf("expected a spiral of radius 0 to hold the center, got %+v", spiral)
+	}
+
+	spiral := Spiral(H{1, 1}, 3)
+	area := Range(H{1, 1}, 3)
+	if len(spiral) != len(area) || spiral[0] != (H{1, 1}) {
+		t.Errorf("expected a spiral of %d starting at the center, got %+v", len(area), spiral)
+	}
+	for k, h := range spiral {
+		if !area[h] {
+			t.Errorf("index %d: %+v isn't in range", k, h)
+		}
+		if k > 0 && Length(Subtract(h, H{1, 1})) < Length(Subtract(spiral[k-1], H{1, 1})) {
+			t.Errorf("index %d: %+v moves back towards the center", k, h)
+		}
+	}
+}
+
 // I need to perform Vertex operations on a hex.
 // rational, needed to draw the grid and this allows me to compute triangles.
 func TestVertices(t *testing.T) {
@@ -331,6 +551,91 @@ func TestVertices(t *testing.T) {
 	}
 }
 
+// I need to visit hex without building a set.
+// Rational, allocating a map every frame is too slow for a game engine.
+func TestEachIn(t *testing.T) {
+	collect := func(walk func(func(H) bool)) []H {
+		var result []H
+		walk(func(h H) bool {
+			result = append(result, h)
+			return true
+		})
+		return result
+	}
+	sameSet := func(name string, a []H, b map[H]bool) {
+		if len(a) != len(b) {
+			t.Errorf("%s: expected %d hexagons, got %d", name, len(b), len(a))
+		}
+		for _, h := range a {
+			if !b[h] {
+				t.Errorf("%s: unexpected %+v", name, h)
+			}
+		}
+	}
+
+	for rad := 0; rad < 5; rad++ {
+		sameSet("range", collect(func(fn func(H) bool) { EachInRange(H{3, -1}, rad, fn) }), Range(H{3, -1}, rad))
+		sameSet("ring", collect(func(fn func(H) bool) { EachInRing(H{3, -1}, rad, fn) }), Ring(H{3, -1}, rad))
+	}
+
+	line := collect(func(fn func(H) bool) { EachInLine(H{4, 1}, H{16, 4}, fn) })
+	for k, h := range Line(H{4, 1}, H{16, 4}) {
+		if line[k] != h {
+			t.Errorf("line index %d: expected %+v, got %+v", k, h, line[k])
+		}
+	}
+
+	layouts := []Layout{
+		MakeLayout(F{10, 10}, F{}, OrientationFlat),
+		MakeLayout(F{10, 10}, F{3.5, -7}, OrientationPointy),
+		MakeLayout(F{12, 7}, F{40, 40}, OrientationFlat),
+		MakeLayout(F{6, 15}, F{}, OrientationPointy),
+	}
+	scratch := map[H]bool{}
+	for _, layout := range layouts {
+		for _, center := range []H{{0, 0}, {4, -9}} {
+			for rad := 0.; rad < 200; rad += 0.7 {
+				area := collect(func(fn func(H) bool) { layout.EachInArea(center, rad, scratch, fn) })
+				sameSet(fmt.Sprintf("area %+v %+v %.1f", layout.Radius, center, rad), area, layout.AreaFor(center, rad))
+			}
+		}
+	}
+
+	count := 0
+	EachInRange(H{0, 0}, 3, func(h H) bool {
+		count++
+		return count < 4
+	})
+	if count != 4 {
+		t.Errorf("expected the walk to stop after 4 hexagons, got %d", count)
+	}
+}
+
+// I need the iterators to never allocate.
+// Rational, garbage collection pauses show up as dropped frames.
+func TestEachInAllocations(t *testing.T) {
+	layout := MakeLayout(F{64, 64}, F{}, OrientationPointy)
+	scratch := map[H]bool{}
+	count := 0
+	visit := func(h H) bool {
+		count++
+		return true
+	}
+	plan := map[string]func(){
+		"range": func() { EachInRange(H{20, 20}, 40, visit) },
+		"ring":  func() { EachInRing(H{20, 20}, 40, visit) },
+		"line":  func() { EachInLine(H{256, 256}, H{-256, 256}, visit) },
+		"area":  func() { layout.EachInArea(H{20, 20}, 512, scratch, visit) },
+	}
+	for name, walk := range plan {
+		// Nothing may survive a collection to hide allocations.
+		runtime.GC()
+		if allocs := testing.AllocsPerRun(10, walk); allocs != 0 {
+			t.Errorf("%s: expected no allocations, got %f", name, allocs)
+		}
+	}
+}
+
 // I need all features to be fast for a game engine.
 // needs definition of fast.
 // rational, these functions will be invoked frequently as part of calculating the game.
@@ -379,3 +684,33 @@ func BenchmarkArea(b *testing.B) {
 		Range(H{20, 20}, 40)
 	}
 }
+
+func BenchmarkEachInLine(b *testing.B) {
+	b.ReportAllocs()
+	for h := 0; h < b.N; h++ {
+		EachInLine(H{256, 256}, H{-256, 256}, func(H) bool { return true })
+	}
+}
+
+func BenchmarkEachInRing(b *testing.B) {
+	b.ReportAllocs()
+	for h := 0; h < b.N; h++ {
+		EachInRing(H{20, 20}, 40, func(H) bool { return true })
+	}
+}
+
+func BenchmarkEachInRange(b *testing.B) {
+	b.ReportAllocs()
+	for h := 0; h < b.N; h++ {
+		EachInRange(H{20, 20}, 40, func(H) bool { return true })
+	}
+}
+
+func BenchmarkScreenEachInArea(b *testing.B) {
+	b.ReportAllocs()
+	layout := MakeLayout(F{64, 64}, F{}, OrientationPointy)
+	scratch := map[H]bool{}
+	for h := 0; h < b.N; h++ {
+		layout.EachInArea(H{h, h}, 512, scratch, func(H) bool { return true })
+	}
+}
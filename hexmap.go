@@ -0,0 +1,170 @@
+package hexagolang
+
+import (
+	"sort"
+)
+
+// HexMap stores a value for each hexagon of a sparse grid.
+type HexMap struct {
+	values map[H]interface{}
+	bounds Bounds
+	stale  bool
+}
+
+// Bounds is the smallest cube aligned box containing a set of hexagons.
+type Bounds struct {
+	Min, Max D // Min and Max hold the lowest and highest Q, R and S seen.
+}
+
+// Contains reports if the hex is inside the bounds.
+func (b Bounds) Contains(h H) bool {
+	d := h.Delta()
+	return d.Q >= b.Min.Q && d.Q <= b.Max.Q &&
+		d.R >= b.Min.R && d.R <= b.Max.R &&
+		d.S >= b.Min.S && d.S <= b.Max.S
+}
+
+// extend grows the bounds to include the hex.
+func (b Bounds) extend(h H) Bounds {
+	d := h.Delta()
+	return Bounds{
+		Min: D{intMin(b.Min.Q, d.Q), intMin(b.Min.R, d.R), intMin(b.Min.S, d.S)},
+		Max: D{intMax(b.Max.Q, d.Q), intMax(b.Max.R, d.R), intMax(b.Max.S, d.S)},
+	}
+}
+
+// NewHexMap creates an empty map.
+func NewHexMap() *HexMap {
+	return &HexMap{
+		values: make(map[H]interface{}),
+	}
+}
+
+// Len returns the number of hexagons holding a value.
+func (m *HexMap) Len() int {
+	return len(m.values)
+}
+
+// Get returns the value stored for the hex and if one was present.
+func (m *HexMap) Get(h H) (interface{}, bool) {
+	v, ok := m.values[h]
+	return v, ok
+}
+
+// Has reports if a value is stored for the hex.
+func (m *HexMap) Has(h H) bool {
+	_, ok := m.values[h]
+	return ok
+}
+
+// Set stores a value for the hex.
+func (m *HexMap) Set(h H, v interface{}) {
+	if len(m.values) == 0 {
+		m.bounds = Bounds{Min: h.Delta(), Max: h.Delta()}
+		m.stale = false
+	} else if !m.stale {
+		m.bounds = m.bounds.extend(h)
+	}
+	m.values[h] = v
+}
+
+// Delete removes the value stored for the hex.
+func (m *HexMap) Delete(h H) {
+	if _, ok := m.values[h]; !ok {
+		return
+	}
+	delete(m.values, h)
+	// Shrinking the bounds needs a full scan, postpone it until asked.
+	m.stale = true
+}
+
+// Bounds returns the bounds of all stored hexagons, false when the map is empty.
+func (m *HexMap) Bounds() (Bounds, bool) {
+	if len(m.values) == 0 {
+		return Bounds{}, false
+	}
+	if m.stale {
+		first := true
+		for h := range m.values {
+			if first {
+				m.bounds = Bounds{Min: h.Delta(), Max: h.Delta()}
+				first = false
+				continue
+			}
+			m.bounds = m.bounds.extend(h)
+		}
+		m.stale = false
+	}
+	return m.bounds, true
+}
+
+// Keys returns the stored hexagons ordered by row then column.
+func (m *HexMap) Keys() []H {
+	result := make([]H, 0, len(m.values))
+	for h := range m.values {
+		result = append(result, h)
+	}
+	sortHexes(result)
+	return result
+}
+
+// Each calls fn for every stored hexagon in the order of Keys, stopping when fn returns false.
+func (m *HexMap) Each(fn func(h H, v interface{}) bool) {
+	for _, h := range m.Keys() {
+		if !fn(h, m.values[h]) {
+			return
+		}
+	}
+}
+
+// Neighbors returns the values stored on the sides of a hex, keyed by direction.
+func (m *HexMap) Neighbors(h H) map[DirectionEnum]interface{} {
+	result := make(map[DirectionEnum]interface{}, 6)
+	for d := DirectionPosQ; d < DirectionUndefined; d++ {
+		if v, ok := m.values[h.Neighbor(d)]; ok {
+			result[d] = v
+		}
+	}
+	return result
+}
+
+// Select returns the stored values for each hex of a set such as Range, Ring or AreaFor.
+func (m *HexMap) Select(set map[H]bool) map[H]interface{} {
+	result := make(map[H]interface{})
+	for h, in := range set {
+		if v, ok := m.values[h]; in && ok {
+			result[h] = v
+		}
+	}
+	return result
+}
+
+// SelectLine returns the stored values along a path such as Line, skipping empty hexagons.
+func (m *HexMap) SelectLine(path []H) []interface{} {
+	result := make([]interface{}, 0, len(path))
+	for _, h := range path {
+		if v, ok := m.values[h]; ok {
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+// Fill stores the same value on each hex of a set.
+func (m *HexMap) Fill(set map[H]bool, v interface{}) {
+	for h, in := range set {
+		if in {
+			m.Set(h, v)
+		}
+	}
+}
+
+// sortHexes orders hexagons by row then column so results are repeatable.
+func sortHexes(hexes []H) {
+	sort.Slice(hexes, func(i, j int) bool {
+		if hexes[i].R != hexes[j].R {
+			return hexes[i].R < hexes[j].R
+		}
+		return hexes[i].Q < hexes[j].Q
+	})
+}
@@ -0,0 +1,82 @@
+package hexagolang
+
+import (
+	"testing"
+)
+
+// I need to store data for each hex of a map.
+// Rational, every game keeps tile data keyed by the hex it sits on.
+func TestHexMap(t *testing.T) {
+	m := NewHexMap()
+	if _, ok := m.Bounds(); ok {
+		t.Errorf("empty map should not have bounds")
+	}
+
+	m.Fill(Range(H{0, 0}, 1), "grass")
+	m.Set(H{2, -1}, "water")
+	if m.Len() != 8 {
+		t.Errorf("expected 8 values, got %d", m.Len())
+	}
+	if v, ok := m.Get(H{2, -1}); !ok || v != "water" {
+		t.Errorf("expected water, got %v %v", v, ok)
+	}
+	if m.Has(H{3, 3}) {
+		t.Errorf("expected H{3, 3} to be empty")
+	}
+
+	neighbors := m.Neighbors(H{1, -1})
+	if len(neighbors) != 4 {
+		t.Errorf("expected 4 neighbors, got %+v", neighbors)
+	}
+	if neighbors[DirectionPosQ] != "water" {
+		t.Errorf("expected water to the PosQ side, got %v", neighbors[DirectionPosQ])
+	}
+
+	b, _ := m.Bounds()
+	if b.Min != (D{-1, -1, -1}) || b.Max != (D{2, 1, 1}) {
+		t.Errorf("unexpected bounds %+v", b)
+	}
+	m.Delete(H{2, -1})
+	b, _ = m.Bounds()
+	if b.Min != (D{-1, -1, -1}) || b.Max != (D{1, 1, 1}) {
+		t.Errorf("unexpected bounds after delete %+v", b)
+	}
+	if !b.Contains(H{1, -1}) || b.Contains(H{2, -1}) {
+		t.Errorf("bounds containment is wrong for %+v", b)
+	}
+
+	if selected := m.Select(Range(H{1, 0}, 1)); len(selected) != 4 {
+		t.Errorf("expected 4 selected values, got %+v", selected)
+	}
+	if selected := m.SelectLine(Line(H{-1, 0}, H{3, 0})); len(selected) != 3 {
+		t.Errorf("expected 3 values on the line, got %+v", selected)
+	}
+}
+
+// I need to walk the map the same way every time.
+// Rational, simulations and renderers must be deterministic.
+func TestHexMapOrder(t *testing.T) {
+	m := NewHexMap()
+	for h := range Range(H{5, 5}, 3) {
+		m.Set(h, h.Q)
+	}
+	keys := m.Keys()
+	for k := 1; k < len(keys); k++ {
+		a, b := keys[k-1], keys[k]
+		if a.R > b.R || (a.R == b.R && a.Q >= b.Q) {
+			t.Errorf("index %d: %+v sorted before %+v", k, a, b)
+		}
+	}
+
+	visited := 0
+	m.Each(func(h H, v interface{}) bool {
+		if h != keys[visited] || v != h.Q {
+			t.Errorf("index %d: expected %+v, got %+v = %v", visited, keys[visited], h, v)
+		}
+		visited++
+		return visited < 5
+	})
+	if visited != 5 {
+		t.Errorf("expected early stop after 5, got %d", visited)
+	}
+}
@@ -0,0 +1,74 @@
+package hexagolang
+
+// Offset coordinates as described in
+// https://www.redblobgames.com/grids/hexagons/#coordinates-offset
+
+// Offset is a hexagon in a column and row array.
+type Offset struct {
+	Col, Row int
+}
+
+// OffsetEnum is the layout of the rows or columns in offset coordinates.
+type OffsetEnum int
+
+// String returns the string name of the offset layout.
+func (o OffsetEnum) String() string {
+	ret := "OffsetUndefined"
+	switch o {
+	case OffsetOddR:
+		ret = "OffsetOddR"
+	case OffsetEvenR:
+		ret = "OffsetEvenR"
+	case OffsetOddQ:
+		ret = "OffsetOddQ"
+	case OffsetEvenQ:
+		ret = "OffsetEvenQ"
+	}
+	return ret
+}
+
+// Constants for the offset layouts.
+// The R layouts shove every other row and go with OrientationPointy,
+// the Q layouts shove every other column and go with OrientationFlat.
+const (
+	OffsetOddR OffsetEnum = iota
+	OffsetEvenR
+	OffsetOddQ
+	OffsetEvenQ
+	OffsetUndefined
+)
+
+// Offset converts the hex to offset coordinates.
+func (h H) Offset(o OffsetEnum) Offset {
+	switch o {
+	case OffsetOddR:
+		return Offset{Col: h.Q + (h.R-(h.R&1))/2, Row: h.R}
+	case OffsetEvenR:
+		return Offset{Col: h.Q + (h.R+(h.R&1))/2, Row: h.R}
+	case OffsetOddQ:
+		return Offset{Col: h.Q, Row: h.R + (h.Q-(h.Q&1))/2}
+	case OffsetEvenQ:
+		return Offset{Col: h.Q, Row: h.R + (h.Q+(h.Q&1))/2}
+	}
+	return Offset{}
+}
+
+// Hex converts the offset coordinates to a hex.
+func (c Offset) Hex(o OffsetEnum) H {
+	switch o {
+	case OffsetOddR:
+		return H{Q: c.Col - (c.Row-(c.Row&1))/2, R: c.Row}
+	case OffsetEvenR:
+		return H{Q: c.Col - (c.Row+(c.Row&1))/2, R: c.Row}
+	case OffsetOddQ:
+		return H{Q: c.Col, R: c.Row - (c.Col-(c.Col&1))/2}
+	case OffsetEvenQ:
+		return H{Q: c.Col, R: c.Row - (c.Col+(c.Col&1))/2}
+	}
+	return H{}
+}
+
+// Neighbor one step in a specific direction.
+func (c Offset) Neighbor(o OffsetEnum, d DirectionEnum) Offset {
+	return c.Hex(o).Neighbor(d).Offset(o)
+}
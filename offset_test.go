@@ -0,0 +1,68 @@
+package hexagolang
+
+import (
+	"math"
+	"testing"
+)
+
+// I need to translate between offset coordinates and hex coordinates.
+// Rational, level editors store maps as rows and columns.
+func TestOffset(t *testing.T) {
+	plan := []struct {
+		o OffsetEnum
+		h H
+		c Offset
+	}{
+		{OffsetOddR, H{0, 0}, Offset{0, 0}},
+		{OffsetOddR, H{0, 1}, Offset{0, 1}},
+		{OffsetOddR, H{-1, 2}, Offset{0, 2}},
+		{OffsetOddR, H{2, -3}, Offset{0, -3}},
+		{OffsetEvenR, H{0, 1}, Offset{1, 1}},
+		{OffsetEvenR, H{-1, 2}, Offset{0, 2}},
+		{OffsetEvenR, H{2, -3}, Offset{1, -3}},
+		{OffsetOddQ, H{1, 0}, Offset{1, 0}},
+		{OffsetOddQ, H{2, -1}, Offset{2, 0}},
+		{OffsetOddQ, H{-3, 2}, Offset{-3, 0}},
+		{OffsetEvenQ, H{1, 0}, Offset{1, 1}},
+		{OffsetEvenQ, H{2, -1}, Offset{2, 0}},
+		{OffsetEvenQ, H{-3, 2}, Offset{-3, 1}},
+	}
+
+	for tc, expected := range plan {
+		if result := expected.h.Offset(expected.o); result != expected.c {
+			t.Errorf("index %d: %s of %+v expected %+v, got %+v", tc, expected.o, expected.h, expected.c, result)
+		}
+		if result := expected.c.Hex(expected.o); result != expected.h {
+			t.Errorf("index %d: %s hex of %+v expected %+v, got %+v", tc, expected.o, expected.c, expected.h, result)
+		}
+	}
+
+	for o := OffsetOddR; o < OffsetUndefined; o++ {
+		for h := range Range(H{1, -2}, 4) {
+			if result := h.Offset(o).Hex(o); result != h {
+				t.Errorf("%s: round trip of %+v gave %+v", o, h, result)
+			}
+			for d := DirectionPosQ; d < DirectionUndefined; d++ {
+				if result := h.Offset(o).Neighbor(o, d); result != h.Neighbor(d).Offset(o) {
+					t.Errorf("%s: neighbor %s of %+v gave %+v", o, d, h, result)
+				}
+			}
+		}
+	}
+}
+
+// I need offset rows to line up on the screen.
+// Rational, loaded maps must look like they did in the editor.
+func TestOffsetRows(t *testing.T) {
+	layout := MakeLayout(F{10, 10}, F{}, OrientationPointy)
+	width := math.Sqrt(3.) * 10
+	for row := -3; row <= 3; row++ {
+		for col := -3; col <= 3; col++ {
+			c := layout.CenterFor(Offset{col, row}.Hex(OffsetOddR))
+			x := width * (float64(col) + 0.5*float64(row&1))
+			if math.Abs(c.X-x) > 0.0001 || math.Abs(c.Y-15*float64(row)) > 0.0001 {
+				t.Errorf("offset %d,%d expected %f,%f, got %+v", col, row, x, 15*float64(row), c)
+			}
+		}
+	}
+}
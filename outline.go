@@ -0,0 +1,60 @@
+package hexagolang
+
+// Boundary returns the closed loops of vertices separating the region from the rest of the grid.
+// The last vertex of a loop connects back to the first. Outer boundaries run
+// counter clockwise on the screen and the boundaries of holes run clockwise.
+func (r Region) Boundary() [][]Vertex {
+	next := make(map[Vertex]Vertex)
+	var starts []Vertex
+	for _, h := range r.Hexes() {
+		for d := DirectionPosQ; d < DirectionUndefined; d++ {
+			if r[h.Neighbor(d)] {
+				continue
+			}
+			// Side d runs from the corner before it to the corner after it.
+			from := MakeVertex(h, Diagonal((d+5)%6))
+			next[from] = MakeVertex(h, Diagonal(d))
+			starts = append(starts, from)
+		}
+	}
+
+	var result [][]Vertex
+	used := make(map[Vertex]bool, len(next))
+	for _, start := range starts {
+		if used[start] {
+			continue
+		}
+		var loop []Vertex
+		for v := start; !used[v]; v = next[v] {
+			used[v] = true
+			loop = append(loop, v)
+		}
+		result = append(result, loop)
+	}
+	return result
+}
+
+// Outline returns the boundary of the region as closed polygons in pixels.
+// The last point of a polygon connects back to the first. Outer boundaries run
+// counter clockwise on the screen and the boundaries of holes run clockwise.
+func (l Layout) Outline(r Region) [][]F {
+	boundary := r.Boundary()
+	result := make([][]F, len(boundary))
+	for k, loop := range boundary {
+		result[k] = make([]F, len(loop))
+		for j, v := range loop {
+			result[k][j] = l.CenterForVertex(v)
+		}
+	}
+	return result
+}
+
+// signedArea returns twice the area of a polygon, negative when it runs counter clockwise on the screen.
+func signedArea(polygon []F) float64 {
+	area := 0.
+	for k := range polygon {
+		a, b := polygon[k], polygon[(k+1)%len(polygon)]
+		area += a.X*b.Y - b.X*a.Y
+	}
+	return area
+}
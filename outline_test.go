@@ -0,0 +1,63 @@
+package hexagolang
+
+import (
+	"math"
+	"testing"
+)
+
+// I need the borders of a set of hexagons.
+// Rational, territories are drawn as one outline instead of a stroke per hex.
+func TestOutline(t *testing.T) {
+	l := MakeLayout(F{10, 10}, F{0, 0}, OrientationPointy)
+	donut := Region(Range(H{0, 0}, 2)).Difference(Region(Range(H{0, 0}, 1)))
+
+	plan := []struct {
+		name    string
+		region  Region
+		lengths []int // lengths of the polygons in order
+		holes   int
+	}{
+		{"empty", Region{}, nil, 0},
+		{"single", MakeRegion(H{3, -1}), []int{6}, 0},
+		{"pair", MakeRegion(H{0, 0}, H{1, 0}), []int{10}, 0},
+		{"apart", MakeRegion(H{0, 0}, H{2, 0}), []int{6, 6}, 0},
+		{"hexagon", Region(Range(H{0, 0}, 1)), []int{18}, 0},
+		{"donut", donut, []int{30, 18}, 1},
+	}
+	for _, params := range plan {
+		outline := l.Outline(params.region)
+		if len(outline) != len(params.lengths) {
+			t.Errorf("%s: expected %d polygons, got %d", params.name, len(params.lengths), len(outline))
+			continue
+		}
+		holes := 0
+		for k, polygon := range outline {
+			if len(polygon) != params.lengths[k] {
+				t.Errorf("%s: polygon %d: expected %d points, got %d", params.name, k, params.lengths[k], len(polygon))
+			}
+			for j := range polygon {
+				side := polygon[(j+1)%len(polygon)].Subtract(polygon[j])
+				if length := math.Hypot(side.X, side.Y); math.Abs(length-10) > 1e-9 {
+					t.Errorf("%s: polygon %d: side %d is %f long", params.name, k, j, length)
+				}
+			}
+			if signedArea(polygon) > 0 {
+				holes++
+			}
+		}
+		if holes != params.holes {
+			t.Errorf("%s: expected %d holes, got %d", params.name, params.holes, holes)
+		}
+	}
+
+	corners := l.corners(H{3, -1})
+	for _, p := range l.Outline(MakeRegion(H{3, -1}))[0] {
+		found := false
+		for _, c := range corners {
+			found = found || math.Hypot(p.X-c.X, p.Y-c.Y) < 1e-9
+		}
+		if !found {
+			t.Errorf("%+v isn't a corner of the hex", p)
+		}
+	}
+}
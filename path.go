@@ -0,0 +1,188 @@
+package hexagolang
+
+import (
+	"container/heap"
+	"math"
+)
+
+// AStar finds the cheapest path between two hexagons.
+// cost returns the price of stepping from a hex to its neighbor, and false when the step is blocked.
+// Steps should cost at least 1 so the hex distance stays an admissible estimate.
+// The search only stops at the goal or when nothing is left to explore, so cost must
+// block steps leaving a finite map whenever the goal might be walled off.
+// A nil path is returned when the goal can't be reached.
+func AStar(start, goal H, cost func(from, to H) (float64, bool)) ([]H, float64) {
+	if start == goal {
+		return []H{start}, 0
+	}
+
+	sx, sy := straightPoint(start)
+	gx, gy := straightPoint(goal)
+	deviation := func(h H) float64 {
+		x, y := straightPoint(h)
+		return math.Abs((x-sx)*(gy-sy) - (y-sy)*(gx-sx))
+	}
+
+	parents := map[H]H{start: start}
+	spent := map[H]float64{start: 0}
+	closed := map[H]bool{}
+	open := &pathQueue{{h: start, estimate: float64(Length(Subtract(goal, start)))}}
+
+	for open.Len() > 0 {
+		current := heap.Pop(open).(pathNode)
+		if closed[current.h] {
+			continue
+		}
+		if current.h == goal {
+			return walkParents(parents, start, goal), spent[goal]
+		}
+		closed[current.h] = true
+
+		for d := DirectionPosQ; d < DirectionUndefined; d++ {
+			next := current.h.Neighbor(d)
+			if closed[next] {
+				continue
+			}
+			step, ok := cost(current.h, next)
+			if !ok {
+				continue
+			}
+			total := spent[current.h] + step
+			if prev, seen := spent[next]; seen && prev <= total {
+				continue
+			}
+			spent[next] = total
+			parents[next] = current.h
+			estimate := float64(Length(Subtract(goal, next)))
+			heap.Push(open, pathNode{
+				h:         next,
+				priority:  total + estimate,
+				estimate:  estimate,
+				deviation: deviation(next),
+			})
+		}
+	}
+	return nil, 0
+}
+
+// Reach is a hex found by Reachable.
+type Reach struct {
+	Remaining int // Remaining movement points after entering the hex.
+	Parent    H   // Parent is the previous hex on the cheapest route, the start is its own parent.
+}
+
+// ReachSet is every hex found by Reachable.
+type ReachSet map[H]Reach
+
+// Path returns the cheapest route from the start to h, nil when h wasn't reached.
+func (r ReachSet) Path(h H) []H {
+	if _, ok := r[h]; !ok {
+		return nil
+	}
+	var result []H
+	for {
+		result = append(result, h)
+		parent := r[h].Parent
+		if parent == h {
+			break
+		}
+		h = parent
+	}
+	reverseHexes(result)
+	return result
+}
+
+// Reachable returns the hexagons that can be entered from start without spending more than budget.
+// cost returns the non negative price of entering a hex, and false when it can't be entered.
+func Reachable(start H, budget int, cost func(H) (int, bool)) ReachSet {
+	if budget < 0 {
+		return ReachSet{}
+	}
+	result := ReachSet{start: {Remaining: budget, Parent: start}}
+	closed := map[H]bool{}
+	open := &pathQueue{{h: start}}
+
+	for open.Len() > 0 {
+		current := heap.Pop(open).(pathNode)
+		if closed[current.h] {
+			continue
+		}
+		closed[current.h] = true
+		left := result[current.h].Remaining
+
+		for d := DirectionPosQ; d < DirectionUndefined; d++ {
+			next := current.h.Neighbor(d)
+			if closed[next] {
+				continue
+			}
+			step, ok := cost(next)
+			if !ok || step > left {
+				continue
+			}
+			if prev, seen := result[next]; seen && prev.Remaining >= left-step {
+				continue
+			}
+			result[next] = Reach{Remaining: left - step, Parent: current.h}
+			heap.Push(open, pathNode{h: next, priority: float64(budget - left + step)})
+		}
+	}
+	return result
+}
+
+// walkParents rebuilds the path ending at goal from a parent lookup.
+func walkParents(parents map[H]H, start, goal H) []H {
+	var result []H
+	for h := goal; h != start; h = parents[h] {
+		result = append(result, h)
+	}
+	result = append(result, start)
+	reverseHexes(result)
+	return result
+}
+
+// reverseHexes reverses the order of a slice in place.
+func reverseHexes(hexes []H) {
+	for i, j := 0, len(hexes)-1; i < j; i, j = i+1, j-1 {
+		hexes[i], hexes[j] = hexes[j], hexes[i]
+	}
+}
+
+// straightPoint places a hex on a unit pointy grid to measure how far it strays from a line.
+func straightPoint(h H) (float64, float64) {
+	q, r := float64(h.Q), float64(h.R)
+	return math.Sqrt(3.) * (q + r/2.), 3. / 2. * r
+}
+
+// pathNode is an entry of the open set of AStar.
+type pathNode struct {
+	h         H
+	priority  float64 // spent + estimate.
+	estimate  float64 // remaining hex distance, prefer nodes nearer the goal.
+	deviation float64 // distance from the straight line, prefer straight paths.
+}
+
+// pathQueue is a min heap of pathNode.
+type pathQueue []pathNode
+
+func (q pathQueue) Len() int { return len(q) }
+
+func (q pathQueue) Less(i, j int) bool {
+	if q[i].priority != q[j].priority {
+		return q[i].priority < q[j].priority
+	}
+	if q[i].estimate != q[j].estimate {
+		return q[i].estimate < q[j].estimate
+	}
+	return q[i].deviation < q[j].deviation
+}
+
+func (q pathQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+
+func (q *pathQueue) Push(x interface{}) { *q = append(*q, x.(pathNode)) }
+
+func (q *pathQueue) Pop() interface{} {
+	old := *q
+	n := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return n
+}
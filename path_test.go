@@ -0,0 +1,165 @@
+package hexagolang
+
+import (
+	"testing"
+)
+
+// I need to find the cheapest path between two hex.
+// Rational, units must route around blocked tiles.
+func TestAStar(t *testing.T) {
+	board := Range(H{0, 0}, 6)
+	wall := map[H]bool{
+		{2, -2}: true, {2, -1}: true, {2, 0}: true, {2, 1}: true, {2, 2}: true,
+	}
+	swamp := map[H]bool{{0, 2}: true}
+	cost := func(from, to H) (float64, bool) {
+		if !board[to] || wall[to] {
+			return 0, false
+		}
+		if swamp[to] {
+			return 5, true
+		}
+		return 1, true
+	}
+
+	plan := []struct {
+		a, b  H
+		steps int
+		cost  float64
+	}{
+		{H{0, 0}, H{0, 0}, 1, 0},
+		{H{-3, 0}, H{1, 0}, 5, 4},
+		{H{0, 0}, H{4, 0}, 9, 8},
+		{H{0, 0}, H{0, 4}, 6, 5},
+		{H{0, 0}, H{-3, 3}, 4, 3},
+		{H{0, 0}, H{9, 0}, 0, 0},
+	}
+
+	for tc, expected := range plan {
+		path, total := AStar(expected.a, expected.b, cost)
+		if len(path) != expected.steps {
+			t.Errorf("index %d: expected %d steps, got %d", tc, expected.steps, len(path))
+			t.Logf("\tpath was %+v", path)
+		}
+		if total != expected.cost {
+			t.Errorf("index %d: expected cost %f, got %f", tc, expected.cost, total)
+		}
+		if len(path) == 0 {
+			continue
+		}
+		if path[0] != expected.a || path[len(path)-1] != expected.b {
+			t.Errorf("index %d: path %+v doesn't run from %+v to %+v", tc, path, expected.a, expected.b)
+		}
+		for k := 1; k < len(path); k++ {
+			if Length(Subtract(path[k], path[k-1])) != 1 || wall[path[k]] {
+				t.Errorf("index %d-%d: invalid step %+v to %+v", tc, k, path[k-1], path[k])
+			}
+		}
+	}
+}
+
+// I need paths on open ground to look like lines.
+// Rational, zig zagging units look broken.
+func TestAStarStraight(t *testing.T) {
+	open := func(from, to H) (float64, bool) {
+		return 1, Length(to.Delta()) < 20
+	}
+	plan := []struct {
+		a, b H
+	}{
+		{H{0, 0}, H{6, 0}},
+		{H{0, 0}, H{0, -5}},
+		{H{-4, 4}, H{0, 0}},
+		{H{0, 0}, H{4, -8}},
+	}
+	for tc, expected := range plan {
+		path, _ := AStar(expected.a, expected.b, open)
+		line := map[H]bool{}
+		for _, h := range Line(expected.a, expected.b) {
+			line[h] = true
+		}
+		for k, h := range path {
+			if !line[h] {
+				t.Errorf("index %d-%d: %+v strays from the line", tc, k, h)
+				t.Logf("\tpath was %+v", path)
+			}
+		}
+	}
+}
+
+func BenchmarkAStar(b *testing.B) {
+	open := func(from, to H) (float64, bool) {
+		return 1, true
+	}
+	for h := 0; h < b.N; h++ {
+		AStar(H{-20, 0}, H{20, 5}, open)
+	}
+}
+
+// I need to know every hex a unit can move to this turn.
+// Rational, tactics games highlight the movement range before a unit moves.
+func TestReachable(t *testing.T) {
+	forest := map[H]bool{{1, 0}: true, {1, -1}: true}
+	cost := func(h H) (int, bool) {
+		switch {
+		case h == H{0, 1}:
+			return 0, false
+		case forest[h]:
+			return 2, true
+		}
+		return 1, true
+	}
+
+	plan := []struct {
+		start  H
+		budget int
+		pos    map[H]int
+		neg    []H
+		size   int
+	}{
+		{H{0, 0}, -1, nil, []H{{0, 0}}, 0},
+		{H{0, 0}, 0, map[H]int{{0, 0}: 0}, []H{{-1, 0}}, 1},
+		{H{0, 0}, 1,
+			map[H]int{{0, 0}: 1, {-1, 0}: 0, {-1, 1}: 0, {0, -1}: 0},
+			[]H{{1, 0}, {1, -1}, {0, 1}},
+			4,
+		},
+		{H{0, 0}, 2,
+			map[H]int{{1, 0}: 0, {1, -1}: 0, {-2, 2}: 0, {-1, 2}: 0},
+			[]H{{0, 1}, {1, 1}, {2, -1}, {2, 0}},
+			13,
+		},
+	}
+
+	for tc, params := range plan {
+		result := Reachable(params.start, params.budget, cost)
+		if len(result) != params.size {
+			t.Errorf("index %d: Expected %d results, got %d.", tc, params.size, len(result))
+			t.Logf("result was %+v", result)
+		}
+		for h, left := range params.pos {
+			if r, ok := result[h]; !ok || r.Remaining != left {
+				t.Errorf("index %d: expected %+v with %d left, got %+v %v", tc, h, left, r, ok)
+			}
+		}
+		for _, h := range params.neg {
+			if _, ok := result[h]; ok {
+				t.Errorf("index %d: %+v should not be reachable", tc, h)
+			}
+		}
+	}
+
+	result := Reachable(H{0, 0}, 3, cost)
+	path := result.Path(H{0, 2})
+	if len(path) != 4 || path[0] != (H{0, 0}) || path[3] != (H{0, 2}) {
+		t.Errorf("expected a 4 step path around the blocked hex, got %+v", path)
+	}
+	for k := 1; k < len(path); k++ {
+		if path[k] == (H{0, 1}) || Length(Subtract(path[k], path[k-1])) != 1 {
+			t.Errorf("invalid step %+v to %+v", path[k-1], path[k])
+		}
+	}
+	if path := result.Path(H{9, 9}); path != nil {
+		t.Errorf("expected no path to an unreached hex, got %+v", path)
+	}
+}
@@ -0,0 +1,181 @@
+package hexagolang
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"math"
+)
+
+// RasterStyle controls how Draw paints each hex.
+type RasterStyle struct {
+	Fill        func(H) color.Color // Fill returns the inside color of a hex, nil leaves it unpainted.
+	Stroke      func(H) color.Color // Stroke returns the outline color of a hex, nil leaves it unpainted.
+	StrokeWidth float64             // StrokeWidth of the outlines in pixels, 1 when 0.
+	Sprite      func(H) image.Image // Sprite returns an image drawn centered on a hex, nil draws nothing.
+}
+
+// rasterSamples is the number of samples per pixel axis used to anti-alias fills.
+const rasterSamples = 4
+
+// Draw paints the hexagons into dst with anti-aliased edges.
+// Fills are painted first, then sprites, then outlines so neighbors never cover an outline.
+// Fills are anti-aliased together so sides shared by two filled hexagons leave no seam.
+func (l Layout) Draw(dst draw.Image, hexes map[H]bool, style RasterStyle) {
+	var ordered []H
+	for h, in := range hexes {
+		if in {
+			ordered = append(ordered, h)
+		}
+	}
+	sortHexes(ordered)
+
+	if style.Fill != nil {
+		var filled []H
+		var colors []color.Color
+		var area image.Rectangle
+		for _, h := range ordered {
+			if c := style.Fill(h); c != nil {
+				filled = append(filled, h)
+				colors = append(colors, c)
+				area = area.Union(pixelBounds(l.corners(h), 0))
+			}
+		}
+		area = area.Intersect(dst.Bounds())
+		acc := make([]coverage, area.Dx()*area.Dy())
+		for k, h := range filled {
+			l.fillHex(acc, area, h, colors[k])
+		}
+		composite(dst, area, acc)
+	}
+	if style.Sprite != nil {
+		for _, h := range ordered {
+			sprite := style.Sprite(h)
+			if sprite == nil {
+				continue
+			}
+			size := sprite.Bounds().Size()
+			at := AsPoint(l.CenterFor(h)).Sub(size.Div(2))
+			draw.Draw(dst, image.Rectangle{at, at.Add(size)}, sprite, sprite.Bounds().Min, draw.Over)
+		}
+	}
+	if style.Stroke != nil {
+		width := style.StrokeWidth
+		if width == 0 {
+			width = 1
+		}
+		for _, h := range ordered {
+			if c := style.Stroke(h); c != nil {
+				l.strokeHex(dst, h, c, width)
+			}
+		}
+	}
+}
+
+// coverage sums the premultiplied colors covering a pixel and how much of it they cover.
+type coverage struct {
+	r, g, b, a float64
+	total      float64
+}
+
+// fillHex adds the inside of a hex to acc, covering edge pixels by the share of samples inside.
+// acc holds a coverage for each pixel of area, row by row.
+func (l Layout) fillHex(acc []coverage, area image.Rectangle, h H, c color.Color) {
+	corners := l.corners(h)
+	inside := func(f F) bool {
+		for k := range corners {
+			a, b := corners[k], corners[(k+1)%len(corners)]
+			if (b.X-a.X)*(f.Y-a.Y)-(b.Y-a.Y)*(f.X-a.X) < 0 {
+				return false
+			}
+		}
+		return true
+	}
+	sr, sg, sb, sa := c.RGBA()
+	bounds := pixelBounds(corners, 0).Intersect(area)
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			hits := 0
+			for i := 0; i < rasterSamples; i++ {
+				for j := 0; j < rasterSamples; j++ {
+					// Pixel x covers x-0.5 to x+0.5, matching AsPoint.
+					sample := F{
+						X: float64(x) - 0.5 + (float64(i)+0.5)/rasterSamples,
+						Y: float64(y) - 0.5 + (float64(j)+0.5)/rasterSamples,
+					}
+					if inside(sample) {
+						hits++
+					}
+				}
+			}
+			if hits == 0 {
+				continue
+			}
+			share := float64(hits) / (rasterSamples * rasterSamples)
+			p := &acc[(y-area.Min.Y)*area.Dx()+x-area.Min.X]
+			p.r += float64(sr) * share
+			p.g += float64(sg) * share
+			p.b += float64(sb) * share
+			p.a += float64(sa) * share
+			p.total += share
+		}
+	}
+}
+
+// composite paints the summed coverage of area over dst.
+func composite(dst draw.Image, area image.Rectangle, acc []coverage) {
+	for y := area.Min.Y; y < area.Max.Y; y++ {
+		for x := area.Min.X; x < area.Max.X; x++ {
+			p := acc[(y-area.Min.Y)*area.Dx()+x-area.Min.X]
+			if p.total == 0 {
+				continue
+			}
+			// Samples on a shared side count for both hexagons.
+			scale := 1 / math.Max(1, p.total)
+			dr, dg, db, da := dst.At(x, y).RGBA()
+			alpha := p.a * scale / 0xffff
+			over := func(s float64, d uint32) uint16 {
+				return uint16(math.Round(s*scale + float64(d)*(1-alpha)))
+			}
+			dst.Set(x, y, color.RGBA64{over(p.r, dr), over(p.g, dg), over(p.b, db), over(p.a, da)})
+		}
+	}
+}
+
+// strokeHex paints the outline of a hex, fading pixels by their distance from the line.
+func (l Layout) strokeHex(dst draw.Image, h H, c color.Color, width float64) {
+	area := pixelBounds(l.corners(h), width/2+1).Intersect(dst.Bounds())
+	for y := area.Min.Y; y < area.Max.Y; y++ {
+		for x := area.Min.X; x < area.Max.X; x++ {
+			dist := l.nearest(h, F{float64(x), float64(y)})
+			if coverage := math.Min(1, width/2+0.5-dist); coverage > 0 {
+				blend(dst, x, y, c, coverage)
+			}
+		}
+	}
+}
+
+// pixelBounds returns the pixels touched by the points grown by pad.
+func pixelBounds(points [6]F, pad float64) image.Rectangle {
+	min, max := points[0], points[0]
+	for _, p := range points {
+		min = F{math.Min(min.X, p.X), math.Min(min.Y, p.Y)}
+		max = F{math.Max(max.X, p.X), math.Max(max.Y, p.Y)}
+	}
+	return image.Rect(
+		int(math.Floor(min.X-pad)), int(math.Floor(min.Y-pad)),
+		int(math.Ceil(max.X+pad))+1, int(math.Ceil(max.Y+pad))+1,
+	)
+}
+
+// blend paints c over the pixel at x, y with the given coverage.
+func blend(dst draw.Image, x, y int, c color.Color, coverage float64) {
+	sr, sg, sb, sa := c.RGBA()
+	dr, dg, db, da := dst.At(x, y).RGBA()
+	scale := func(v uint32) float64 { return float64(v) * coverage }
+	alpha := scale(sa) / 0xffff
+	over := func(s, d uint32) uint16 {
+		return uint16(math.Round(scale(s) + float64(d)*(1-alpha)))
+	}
+	dst.Set(x, y, color.RGBA64{over(sr, dr), over(sg, dg), over(sb, db), over(sa, da)})
+}
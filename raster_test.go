@@ -0,0 +1,110 @@
+package hexagolang
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+// I need to draw the grid into an image.
+// Rational, thumbnails and minimaps are plain images.
+func TestDraw(t *testing.T) {
+	layout := MakeLayout(F{15, 15}, F{20, 20}, OrientationPointy)
+	red := color.RGBA{255, 0, 0, 255}
+	img := image.NewRGBA(image.Rect(0, 0, 40, 40))
+	layout.Draw(img, map[H]bool{{0, 0}: true}, RasterStyle{
+		Fill: func(H) color.Color { return red },
+	})
+
+	if result := img.RGBAAt(20, 20); result != red {
+		t.Errorf("expected the center to be red, got %+v", result)
+	}
+	if result := img.RGBAAt(0, 0); result.A != 0 {
+		t.Errorf("expected the corner to be empty, got %+v", result)
+	}
+	partial := 0
+	for y := 0; y < 40; y++ {
+		for x := 0; x < 40; x++ {
+			if a := img.RGBAAt(x, y).A; a > 0 && a < 255 {
+				partial++
+			}
+		}
+	}
+	if partial == 0 {
+		t.Errorf("expected anti-aliased pixels along the edges")
+	}
+}
+
+// I need filled areas without seams.
+// Rational, minimaps fill whole territories and a grid of faint lines shows through.
+func TestDrawSeamless(t *testing.T) {
+	layout := MakeLayout(F{15, 15}, F{40, 40}, OrientationPointy)
+	cluster := Range(H{0, 0}, 1)
+	red, blue := color.RGBA{255, 0, 0, 255}, color.RGBA{0, 0, 255, 255}
+	plan := []struct {
+		name string
+		fill func(H) color.Color
+	}{
+		{"one color", func(H) color.Color { return red }},
+		{"two colors", func(h H) color.Color {
+			if h.Q&1 == 0 {
+				return red
+			}
+			return blue
+		}},
+	}
+	for _, params := range plan {
+		img := image.NewRGBA(image.Rect(0, 0, 80, 80))
+		layout.Draw(img, cluster, RasterStyle{Fill: params.fill})
+		for y := 0; y < 80; y++ {
+			for x := 0; x < 80; x++ {
+				// Pixels a full pixel away from the outside of the cluster must be solid.
+				inside := true
+				for _, d := range []F{{-1, -1}, {1, -1}, {-1, 1}, {1, 1}} {
+					inside = inside && cluster[layout.HexFor(F{float64(x) + d.X, float64(y) + d.Y})]
+				}
+				if a := img.RGBAAt(x, y).A; inside && a != 255 {
+					t.Errorf("%s: expected %d,%d to be opaque, got alpha %d", params.name, x, y, a)
+				}
+			}
+		}
+	}
+}
+
+// I need outlines and sprites on top of the fills.
+// Rational, minimaps show borders and unit icons.
+func TestDrawStrokeAndSprite(t *testing.T) {
+	layout := MakeLayout(F{10, 10}, F{20, 20}, OrientationFlat)
+	white, black := color.RGBA{255, 255, 255, 255}, color.RGBA{0, 0, 0, 255}
+	blue := color.RGBA{0, 0, 255, 255}
+	sprite := image.NewRGBA(image.Rect(0, 0, 3, 3))
+	for y := 0; y < 3; y++ {
+		for x := 0; x < 3; x++ {
+			sprite.SetRGBA(x, y, blue)
+		}
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, 60, 40))
+	layout.Draw(img, map[H]bool{{0, 0}: true, {1, 0}: true}, RasterStyle{
+		Fill:        func(H) color.Color { return white },
+		Stroke:      func(H) color.Color { return black },
+		StrokeWidth: 2,
+		Sprite: func(h H) image.Image {
+			if h.Q == 1 {
+				return sprite
+			}
+			return nil
+		},
+	})
+
+	if result := img.RGBAAt(20, 20); result != white {
+		t.Errorf("expected the center of H{0, 0} to be white, got %+v", result)
+	}
+	if result := img.RGBAAt(30, 20); result != black {
+		t.Errorf("expected the corner of H{0, 0} to be black, got %+v", result)
+	}
+	center := AsPoint(layout.CenterFor(H{1, 0}))
+	if result := img.RGBAAt(center.X, center.Y); result != blue {
+		t.Errorf("expected the sprite at the center of H{1, 0}, got %+v", result)
+	}
+}
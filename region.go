@@ -0,0 +1,125 @@
+package hexagolang
+
+// Region is a set of hexagons, the results of Range, Ring, RingFor and AreaFor convert directly.
+// Hexagons mapped to false are treated as missing.
+type Region map[H]bool
+
+// MakeRegion returns a region holding the hexagons.
+func MakeRegion(hexes ...H) Region {
+	result := make(Region, len(hexes))
+	for _, h := range hexes {
+		result[h] = true
+	}
+	return result
+}
+
+// Contains reports if the hex is in the region.
+func (r Region) Contains(h H) bool {
+	return r[h]
+}
+
+// ContainsRegion reports if every hex of o is in the region.
+func (r Region) ContainsRegion(o Region) bool {
+	for h, in := range o {
+		if in && !r[h] {
+			return false
+		}
+	}
+	return true
+}
+
+// Equal reports if both regions hold the same hexagons.
+func (r Region) Equal(o Region) bool {
+	return r.ContainsRegion(o) && o.ContainsRegion(r)
+}
+
+// Hexes returns the hexagons of the region ordered by row then column.
+func (r Region) Hexes() []H {
+	result := make([]H, 0, len(r))
+	for h, in := range r {
+		if in {
+			result = append(result, h)
+		}
+	}
+	sortHexes(result)
+	return result
+}
+
+// Union returns the hexagons in either region.
+func (r Region) Union(o Region) Region {
+	result := make(Region, len(r)+len(o))
+	for h, in := range r {
+		if in {
+			result[h] = true
+		}
+	}
+	for h, in := range o {
+		if in {
+			result[h] = true
+		}
+	}
+	return result
+}
+
+// Intersection returns the hexagons in both regions.
+func (r Region) Intersection(o Region) Region {
+	result := make(Region)
+	for h, in := range r {
+		if in && o[h] {
+			result[h] = true
+		}
+	}
+	return result
+}
+
+// Difference returns the hexagons of the region that aren't in o.
+func (r Region) Difference(o Region) Region {
+	result := make(Region)
+	for h, in := range r {
+		if in && !o[h] {
+			result[h] = true
+		}
+	}
+	return result
+}
+
+// SymmetricDifference returns the hexagons in exactly one of the regions.
+func (r Region) SymmetricDifference(o Region) Region {
+	return r.Difference(o).Union(o.Difference(r))
+}
+
+// Translate returns the region moved by d.
+func (r Region) Translate(d D) Region {
+	return r.apply(func(h H) H { return Add(h, d) })
+}
+
+// RotateClockwise returns the region rotated clockwise around origin.
+func (r Region) RotateClockwise(origin H) Region {
+	return r.apply(func(h H) H { return RotateClockwise(origin, h) })
+}
+
+// RotateCounterClockwise returns the region rotated counter clockwise around origin.
+func (r Region) RotateCounterClockwise(origin H) Region {
+	return r.apply(func(h H) H { return RotateCounterClockwise(origin, h) })
+}
+
+// Rotate returns the region rotated around origin by steps of 60 degrees, counter clockwise when positive.
+func (r Region) Rotate(origin H, steps int) Region {
+	return r.apply(func(h H) H { return Rotate(origin, h, steps) })
+}
+
+// Reflect returns the region mirrored across the axis through origin.
+func (r Region) Reflect(origin H, a AxisEnum) Region {
+	return r.apply(func(h H) H { return Reflect(origin, h, a) })
+}
+
+// apply returns the region with every hex moved by fn.
+func (r Region) apply(fn func(H) H) Region {
+	result := make(Region, len(r))
+	for h, in := range r {
+		if in {
+			result[fn(h)] = true
+		}
+	}
+	return result
+}
@@ -0,0 +1,57 @@
+package hexagolang
+
+import (
+	"testing"
+)
+
+// I need to combine areas of hex.
+// Rational, area of effect and territory rules are unions and differences of areas.
+func TestRegion(t *testing.T) {
+	a := Region(Range(H{0, 0}, 1))
+	b := MakeRegion(H{1, 0}, H{2, 0}, H{3, 0})
+	a[H{9, 9}] = false
+
+	plan := []struct {
+		name   string
+		result Region
+		size   int
+		pos    []H
+		neg    []H
+	}{
+		{"union", a.Union(b), 9, []H{{0, 0}, {3, 0}}, []H{{9, 9}}},
+		{"intersection", a.Intersection(b), 1, []H{{1, 0}}, []H{{0, 0}, {2, 0}}},
+		{"difference", a.Difference(b), 6, []H{{0, 0}, {-1, 0}}, []H{{1, 0}, {2, 0}}},
+		{"symmetric difference", a.SymmetricDifference(b), 8, []H{{0, 0}, {2, 0}}, []H{{1, 0}}},
+		{"translate", b.Translate(D{-1, 1, 0}), 3, []H{{0, 1}, {2, 1}}, []H{{3, 0}}},
+		{"rotate clockwise", b.RotateClockwise(H{0, 0}), 3, []H{{0, 1}, {0, 3}}, []H{{1, 0}}},
+		{"rotate counter clockwise", b.RotateCounterClockwise(H{1, 0}), 3, []H{{1, 0}, {2, -1}, {3, -2}}, nil},
+	}
+	for _, params := range plan {
+		if len(params.result.Hexes()) != params.size {
+			t.Errorf("%s: expected %d hexagons, got %+v", params.name, params.size, params.result.Hexes())
+		}
+		for _, h := range params.pos {
+			if !params.result.Contains(h) {
+				t.Errorf("%s: expected %+v", params.name, h)
+			}
+		}
+		for _, h := range params.neg {
+			if params.result.Contains(h) {
+				t.Errorf("%s: unexpected %+v", params.name, h)
+			}
+		}
+	}
+
+	if !a.Union(b).ContainsRegion(b) || a.ContainsRegion(b) {
+		t.Errorf("containment is wrong")
+	}
+	if !b.Equal(b.RotateClockwise(H{5, 5}).RotateCounterClockwise(H{5, 5})) {
+		t.Errorf("rotating back and forth should be a no-op")
+	}
+	hexes := a.Union(b).Hexes()
+	for k := 1; k < len(hexes); k++ {
+		if hexes[k-1].R > hexes[k].R || (hexes[k-1].R == hexes[k].R && hexes[k-1].Q >= hexes[k].Q) {
+			t.Errorf("index %d: %+v sorted before %+v", k, hexes[k-1], hexes[k])
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package hexagolang
+
+// Map shapes as described in
+// https://www.redblobgames.com/grids/hexagons/implementation.html#map-shapes
+
+// Parallelogram returns every hex with Q and R between the corners, row by row.
+func Parallelogram(min, max H) []H {
+	if min.Q > max.Q || min.R > max.R {
+		return []H{}
+	}
+	results := make([]H, 0, (max.Q-min.Q+1)*(max.R-min.R+1))
+	for r := min.R; r <= max.R; r++ {
+		for q := min.Q; q <= max.Q; q++ {
+			results = append(results, H{q, r})
+		}
+	}
+	return results
+}
+
+// TriangleDown returns a triangle with sides of size+1 hexagons and its top side on row 0, row by row.
+// The triangle points down with OrientationPointy and right with OrientationFlat.
+func TriangleDown(size int) []H {
+	results := make([]H, 0, (size+1)*(size+2)/2)
+	for r := 0; r <= size; r++ {
+		for q := 0; q <= size-r; q++ {
+			results = append(results, H{q, r})
+		}
+	}
+	return results
+}
+
+// TriangleUp returns a triangle with sides of size+1 hexagons and its tip at H{0, 0}, row by row.
+// The triangle points up with OrientationPointy and left with OrientationFlat.
+func TriangleUp(size int) []H {
+	results := make([]H, 0, (size+1)*(size+2)/2)
+	for r := 0; r <= size; r++ {
+		for q := -r; q <= 0; q++ {
+			results = append(results, H{q, r})
+		}
+	}
+	return results
+}
+
+// Hexagon returns every hex within rad of center, row by row.
+// Unlike Range, a radius of 0 returns the center.
+func Hexagon(center H, rad int) []H {
+	if rad < 0 {
+		return []H{}
+	}
+	results := make([]H, 0, 3*rad*(rad+1)+1)
+	for r := -rad; r <= rad; r++ {
+		for q := intMax(-rad, -r-rad); q <= intMin(rad, -r+rad); q++ {
+			results = append(results, Add(center, D{q, r, -q - r}))
+		}
+	}
+	return results
+}
+
+// Rectangle returns a width by height map with H{0, 0} in the top left corner.
+// With OrientationPointy the hexagons are returned row by row, shaped like OffsetOddR.
+// With OrientationFlat the hexagons are returned column by column, shaped like OffsetOddQ.
+func Rectangle(width, height int, o Orientation) []H {
+	if width < 1 || height < 1 {
+		return []H{}
+	}
+	results := make([]H, 0, width*height)
+	if o == OrientationFlat {
+		for q := 0; q < width; q++ {
+			shove := q >> 1
+			for r := -shove; r < height-shove; r++ {
+				results = append(results, H{q, r})
+			}
+		}
+		return results
+	}
+	for r := 0; r < height; r++ {
+		shove := r >> 1
+		for q := -shove; q < width-shove; q++ {
+			results = append(results, H{q, r})
+		}
+	}
+	return results
+}
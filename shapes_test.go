@@ -0,0 +1,86 @@
+package hexagolang
+
+import (
+	"testing"
+)
+
+// I need to build the standard map shapes.
+// Rational, every board starts as a hexagon, rectangle, triangle or parallelogram.
+func TestShapes(t *testing.T) {
+	plan := []struct {
+		name    string
+		shape   []H
+		size    int
+		corners []H
+	}{
+		{"parallelogram", Parallelogram(H{-1, 2}, H{2, 4}), 12, []H{{-1, 2}, {2, 2}, {-1, 4}, {2, 4}}},
+		{"empty parallelogram", Parallelogram(H{2, 2}, H{1, 2}), 0, nil},
+		{"triangle down", TriangleDown(3), 10, []H{{0, 0}, {3, 0}, {0, 3}}},
+		{"triangle up", TriangleUp(3), 10, []H{{0, 0}, {-3, 3}, {0, 3}}},
+		{"single triangle", TriangleUp(0), 1, []H{{0, 0}}},
+		{"hexagon", Hexagon(H{2, 2}, 2), 19, []H{{4, 2}, {0, 2}, {2, 0}, {2, 4}, {4, 0}, {0, 4}}},
+		{"single hexagon", Hexagon(H{2, 2}, 0), 1, []H{{2, 2}}},
+		{"pointy rectangle", Rectangle(4, 3, OrientationPointy), 12, []H{{0, 0}, {3, 0}, {-1, 2}, {2, 2}}},
+		{"flat rectangle", Rectangle(4, 3, OrientationFlat), 12, []H{{0, 0}, {0, 2}, {3, -1}, {3, 1}}},
+	}
+
+	for _, params := range plan {
+		if len(params.shape) != params.size {
+			t.Errorf("%s: expected %d hexagons, got %d", params.name, params.size, len(params.shape))
+			t.Logf("\tshape was %+v", params.shape)
+		}
+		set := map[H]bool{}
+		for _, h := range params.shape {
+			if set[h] {
+				t.Errorf("%s: %+v returned twice", params.name, h)
+			}
+			set[h] = true
+		}
+		for _, h := range params.corners {
+			if !set[h] {
+				t.Errorf("%s: expected corner %+v", params.name, h)
+			}
+		}
+	}
+
+	for h := range Range(H{2, 2}, 2) {
+		found := false
+		for _, v := range Hexagon(H{2, 2}, 2) {
+			found = found || v == h
+		}
+		if !found {
+			t.Errorf("hexagon is missing %+v from Range", h)
+		}
+	}
+}
+
+// I need rectangles to match the rows and columns of offset maps.
+// Rational, rectangular maps are stored as offset arrays.
+func TestRectangle(t *testing.T) {
+	plan := []struct {
+		o      Orientation
+		offset OffsetEnum
+	}{
+		{OrientationPointy, OffsetOddR},
+		{OrientationFlat, OffsetOddQ},
+	}
+	for tc, params := range plan {
+		shape := Rectangle(5, 4, params.o)
+		for k, h := range shape {
+			c := h.Offset(params.offset)
+			if c.Col < 0 || c.Col >= 5 || c.Row < 0 || c.Row >= 4 {
+				t.Errorf("index %d-%d: %+v is outside the rectangle at %+v", tc, k, h, c)
+			}
+			if k == 0 {
+				continue
+			}
+			prev := shape[k-1].Offset(params.offset)
+			if params.o == OrientationPointy && (prev.Row > c.Row || prev.Row == c.Row && prev.Col >= c.Col) {
+				t.Errorf("index %d-%d: %+v returned after %+v", tc, k, c, prev)
+			}
+			if params.o == OrientationFlat && (prev.Col > c.Col || prev.Col == c.Col && prev.Row >= c.Row) {
+				t.Errorf("index %d-%d: %+v returned after %+v", tc, k, c, prev)
+			}
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package hexagolang
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// SVGStyle controls how WriteSVG draws each hex.
+type SVGStyle struct {
+	Fill        func(H) string // Fill returns the fill color of a hex, "none" when nil.
+	Stroke      func(H) string // Stroke returns the outline color of a hex, "black" when nil.
+	StrokeWidth float64        // StrokeWidth of the outlines, 1 when 0.
+	Label       func(H) string // Label returns the text drawn at the center of a hex.
+	Coordinates bool           // Coordinates adds the "q,r" of each hex under its label.
+	Margin      float64        // Margin is the space left around the hexagons.
+}
+
+// WriteSVG writes the hexagons as an SVG document sized to fit them.
+func (l Layout) WriteSVG(w io.Writer, hexes map[H]bool, style SVGStyle) error {
+	var ordered []H
+	for h, in := range hexes {
+		if in {
+			ordered = append(ordered, h)
+		}
+	}
+	sortHexes(ordered)
+
+	width := style.StrokeWidth
+	if width == 0 {
+		width = 1
+	}
+	min, max := F{}, F{}
+	if len(ordered) > 0 {
+		min, max = l.CenterFor(ordered[0]), l.CenterFor(ordered[0])
+	}
+	for _, h := range ordered {
+		for _, v := range l.corners(h) {
+			min = F{math.Min(min.X, v.X), math.Min(min.Y, v.Y)}
+			max = F{math.Max(max.X, v.X), math.Max(max.Y, v.Y)}
+		}
+	}
+	pad := style.Margin + width/2
+	min, max = min.Subtract(F{pad, pad}), max.Add(F{pad, pad})
+	size := max.Subtract(min)
+	font := math.Min(l.Radius.X, l.Radius.Y) * 0.4
+
+	out := &svgWriter{w: w}
+	out.printf(`<svg xmlns="http://www.w3.org/2000/svg" viewBox="%s %s %s %s" width="%s" height="%s">`+"\n",
+		svgNumber(min.X), svgNumber(min.Y), svgNumber(size.X), svgNumber(size.Y),
+		svgNumber(size.X), svgNumber(size.Y))
+
+	for _, h := range ordered {
+		fill, stroke := "none", "black"
+		if style.Fill != nil {
+			fill = style.Fill(h)
+		}
+		if style.Stroke != nil {
+			stroke = style.Stroke(h)
+		}
+		points := make([]string, 0, 6)
+		for _, v := range l.corners(h) {
+			points = append(points, svgNumber(v.X)+","+svgNumber(v.Y))
+		}
+		out.printf(`<polygon points="%s" fill="%s" stroke="%s" stroke-width="%s"/>`+"\n",
+			strings.Join(points, " "), svgEscape(fill), svgEscape(stroke), svgNumber(width))
+	}
+
+	for _, h := range ordered {
+		center := l.CenterFor(h)
+		if style.Label != nil {
+			if label := style.Label(h); label != "" {
+				out.printf(`<text x="%s" y="%s" font-size="%s" text-anchor="middle" dominant-baseline="central">%s</text>`+"\n",
+					svgNumber(center.X), svgNumber(center.Y), svgNumber(font), svgEscape(label))
+			}
+		}
+		if style.Coordinates {
+			below := center.Y + l.Radius.Y*0.5
+			out.printf(`<text x="%s" y="%s" font-size="%s" text-anchor="middle" dominant-baseline="central">%d,%d</text>`+"\n",
+				svgNumber(center.X), svgNumber(below), svgNumber(font*0.6), h.Q, h.R)
+		}
+	}
+
+	out.printf("</svg>\n")
+	return out.err
+}
+
+// svgWriter keeps the first error so writing can carry on without checks.
+type svgWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (s *svgWriter) printf(format string, args ...interface{}) {
+	if s.err != nil {
+		return
+	}
+	_, s.err = fmt.Fprintf(s.w, format, args...)
+}
+
+// svgNumber formats a coordinate with at most three decimals.
+func svgNumber(f float64) string {
+	return strconv.FormatFloat(math.Round(f*1000)/1000, 'f', -1, 64)
+}
+
+// svgEscape makes text safe for attribute values and element content.
+func svgEscape(s string) string {
+	var b bytes.Buffer
+	xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
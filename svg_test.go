@@ -0,0 +1,76 @@
+package hexagolang
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// I need to write the grid as a vector image.
+// Rational, debug maps are easier to share as SVG.
+func TestWriteSVG(t *testing.T) {
+	layout := MakeLayout(F{10, 10}, F{0, 0}, OrientationPointy)
+	hexes := map[H]bool{{0, 0}: true, {1, 0}: true, {5, 5}: false}
+	var out bytes.Buffer
+	err := layout.WriteSVG(&out, hexes, SVGStyle{
+		Fill: func(h H) string {
+			if h.Q == 0 {
+				return "green"
+			}
+			return "blue"
+		},
+		Label:       func(h H) string { return "<town & " + string(rune('A'+h.Q)) + ">" },
+		Coordinates: true,
+		Margin:      2,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	result := out.String()
+
+	var doc struct {
+		ViewBox  string `xml:"viewBox,attr"`
+		Polygons []struct {
+			Points string `xml:"points,attr"`
+			Fill   string `xml:"fill,attr"`
+		} `xml:"polygon"`
+		Texts []string `xml:"text"`
+	}
+	if err := xml.Unmarshal(out.Bytes(), &doc); err != nil {
+		t.Fatalf("invalid svg %v\n%s", err, result)
+	}
+	// Two pointy hexagons side by side are 4 half widths wide and 2 radii tall.
+	if doc.ViewBox != "-11.16 -12.5 39.641 25" {
+		t.Errorf("unexpected viewBox %q", doc.ViewBox)
+	}
+	if len(doc.Polygons) != 2 || doc.Polygons[0].Fill != "green" || doc.Polygons[1].Fill != "blue" {
+		t.Errorf("unexpected polygons %+v", doc.Polygons)
+	}
+	if len(strings.Fields(doc.Polygons[0].Points)) != 6 {
+		t.Errorf("expected 6 points, got %q", doc.Polygons[0].Points)
+	}
+	expected := []string{"<town & A>", "0,0", "<town & B>", "1,0"}
+	if len(doc.Texts) != len(expected) {
+		t.Fatalf("expected texts %q, got %q", expected, doc.Texts)
+	}
+	for k := range expected {
+		if doc.Texts[k] != expected[k] {
+			t.Errorf("index %d: expected text %q, got %q", k, expected[k], doc.Texts[k])
+		}
+	}
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) { return 0, errors.New("disk full") }
+
+// I need to know when writing the image failed.
+// Rational, a half written file should not be mistaken for a map.
+func TestWriteSVGError(t *testing.T) {
+	layout := MakeLayout(F{10, 10}, F{0, 0}, OrientationFlat)
+	if err := layout.WriteSVG(failingWriter{}, Range(H{0, 0}, 2), SVGStyle{}); err == nil {
+		t.Errorf("expected the write error to be returned")
+	}
+}
@@ -0,0 +1,187 @@
+package hexagolang
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// The text forms are "q,r" for a hex, "q,r,s" for a delta and the String names for directions and diagonals.
+// They marshal to JSON strings and work as JSON object keys. Unmarshaling JSON also accepts the
+// older forms, objects for hexagons and deltas and numbers for directions and diagonals.
+
+// ParseH reads a hex written as "q,r".
+func ParseH(s string) (H, error) {
+	values, err := parseInts(s, 2)
+	if err != nil {
+		return H{}, fmt.Errorf("hexagolang: invalid hex %q: %v", s, err)
+	}
+	return H{values[0], values[1]}, nil
+}
+
+// ParseD reads a delta written as "q,r,s".
+func ParseD(s string) (D, error) {
+	values, err := parseInts(s, 3)
+	if err != nil {
+		return D{}, fmt.Errorf("hexagolang: invalid delta %q: %v", s, err)
+	}
+	if values[0]+values[1]+values[2] != 0 {
+		return D{}, fmt.Errorf("hexagolang: invalid delta %q: q+r+s must be 0", s)
+	}
+	return D{values[0], values[1], values[2]}, nil
+}
+
+// ParseDirection reads a direction written by DirectionEnum.String.
+func ParseDirection(s string) (DirectionEnum, error) {
+	for d := DirectionPosQ; d <= DirectionUndefined; d++ {
+		if d.String() == s {
+			return d, nil
+		}
+	}
+	return DirectionUndefined, fmt.Errorf("hexagolang: invalid direction %q", s)
+}
+
+// ParseDiagonal reads a diagonal written by Diagonal.String.
+func ParseDiagonal(s string) (Diagonal, error) {
+	for d := DiagonalPosQ; d <= DiagonalUndefined; d++ {
+		if d.String() == s {
+			return d, nil
+		}
+	}
+	return DiagonalUndefined, fmt.Errorf("hexagolang: invalid diagonal %q", s)
+}
+
+// MarshalText writes the hex as "q,r".
+func (h H) MarshalText() ([]byte, error) {
+	return []byte(strconv.Itoa(h.Q) + "," + strconv.Itoa(h.R)), nil
+}
+
+// UnmarshalText reads the hex from "q,r".
+func (h *H) UnmarshalText(text []byte) error {
+	result, err := ParseH(string(text))
+	if err == nil {
+		*h = result
+	}
+	return err
+}
+
+// UnmarshalJSON reads the hex from a "q,r" string or a {"Q": q, "R": r} object.
+func (h *H) UnmarshalJSON(data []byte) error {
+	type object H
+	return unmarshalJSON(data, h, (*object)(h))
+}
+
+// MarshalText writes the delta as "q,r,s".
+func (d D) MarshalText() ([]byte, error) {
+	return []byte(strconv.Itoa(d.Q) + "," + strconv.Itoa(d.R) + "," + strconv.Itoa(d.S)), nil
+}
+
+// UnmarshalText reads the delta from "q,r,s".
+func (d *D) UnmarshalText(text []byte) error {
+	result, err := ParseD(string(text))
+	if err == nil {
+		*d = result
+	}
+	return err
+}
+
+// UnmarshalJSON reads the delta from a "q,r,s" string or a {"Q": q, "R": r, "S": s} object.
+func (d *D) UnmarshalJSON(data []byte) error {
+	type object D
+	return unmarshalJSON(data, d, (*object)(d))
+}
+
+// MarshalText writes the name of the direction.
+func (d DirectionEnum) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText reads the name of the direction.
+func (d *DirectionEnum) UnmarshalText(text []byte) error {
+	result, err := ParseDirection(string(text))
+	if err == nil {
+		*d = result
+	}
+	return err
+}
+
+// UnmarshalJSON reads the direction from its name or its number.
+func (d *DirectionEnum) UnmarshalJSON(data []byte) error {
+	var number int
+	if err := unmarshalJSON(data, d, &number); err != nil || !isNumber(data) {
+		return err
+	}
+	if number < int(DirectionPosQ) || number > int(DirectionUndefined) {
+		return fmt.Errorf("hexagolang: invalid direction %d", number)
+	}
+	*d = DirectionEnum(number)
+	return nil
+}
+
+// MarshalText writes the name of the diagonal.
+func (d Diagonal) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText reads the name of the diagonal.
+func (d *Diagonal) UnmarshalText(text []byte) error {
+	result, err := ParseDiagonal(string(text))
+	if err == nil {
+		*d = result
+	}
+	return err
+}
+
+// UnmarshalJSON reads the diagonal from its name or its number.
+func (d *Diagonal) UnmarshalJSON(data []byte) error {
+	var number int
+	if err := unmarshalJSON(data, d, &number); err != nil || !isNumber(data) {
+		return err
+	}
+	if number < int(DiagonalPosQ) || number > int(DiagonalUndefined) {
+		return fmt.Errorf("hexagolang: invalid diagonal %d", number)
+	}
+	*d = Diagonal(number)
+	return nil
+}
+
+// unmarshalJSON reads JSON strings with text and anything else into older.
+func unmarshalJSON(data []byte, text encoding.TextUnmarshaler, older interface{}) error {
+	trimmed := strings.TrimSpace(string(data))
+	if trimmed == "null" {
+		return nil
+	}
+	if strings.HasPrefix(trimmed, `"`) {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		return text.UnmarshalText([]byte(s))
+	}
+	return json.Unmarshal(data, older)
+}
+
+// isNumber reports if the JSON value is a number.
+func isNumber(data []byte) bool {
+	trimmed := strings.TrimSpace(string(data))
+	return trimmed != "" && strings.ContainsRune("-0123456789", rune(trimmed[0]))
+}
+
+// parseInts reads count comma separated integers.
+func parseInts(s string, count int) ([]int, error) {
+	fields := strings.Split(s, ",")
+	if len(fields) != count {
+		return nil, fmt.Errorf("expected %d values, got %d", count, len(fields))
+	}
+	values := make([]int, count)
+	for k, field := range fields {
+		v, err := strconv.Atoi(strings.TrimSpace(field))
+		if err != nil {
+			return nil, err
+		}
+		values[k] = v
+	}
+	return values, nil
+}
@@ -0,0 +1,90 @@
+package hexagolang
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// I need to write coordinates to configs and logs and read them back.
+// Rational, saved maps key their hexagons by coordinate in JSON.
+func TestText(t *testing.T) {
+	type config struct {
+		At     H
+		Step   D
+		Facing DirectionEnum
+		Corner Diagonal
+		Tiles  map[H]string
+	}
+	in := config{
+		At:     H{-3, 12},
+		Step:   D{1, -2, 1},
+		Facing: DirectionNegS,
+		Corner: DiagonalPosR,
+		Tiles:  map[H]string{{0, 0}: "grass", {1, -1}: "water"},
+	}
+	data, err := json.Marshal(in)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	expected := `{"At":"-3,12","Step":"1,-2,1","Facing":"DirectionNegS","Corner":"DiagonalPosR","Tiles":{"0,0":"grass","1,-1":"water"}}`
+	if string(data) != expected {
+		t.Errorf("marshal: expected %s, got %s", expected, data)
+	}
+	var out config
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if out.At != in.At || out.Step != in.Step || out.Facing != in.Facing || out.Corner != in.Corner ||
+		len(out.Tiles) != 2 || out.Tiles[H{1, -1}] != "water" {
+		t.Errorf("unmarshal: expected %+v, got %+v", in, out)
+	}
+
+	older := `{"At":{"Q":-3,"R":12},"Step":{"Q":1,"R":-2,"S":1},"Facing":5,"Corner":4}`
+	out = config{}
+	if err := json.Unmarshal([]byte(older), &out); err != nil {
+		t.Fatalf("unmarshal older: %v", err)
+	}
+	if out.At != in.At || out.Step != in.Step || out.Facing != in.Facing || out.Corner != in.Corner {
+		t.Errorf("unmarshal older: expected %+v, got %+v", in, out)
+	}
+
+	plan := []struct {
+		name  string
+		input string
+		valid bool
+	}{
+		{"hex", `{"At":" 4, -2"}`, true},
+		{"hex null", `{"At":null}`, true},
+		{"hex missing r", `{"At":"4"}`, false},
+		{"hex letters", `{"At":"a,b"}`, false},
+		{"delta", `{"Step":"2,-1,-1"}`, true},
+		{"delta off grid", `{"Step":"1,1,1"}`, false},
+		{"direction", `{"Facing":"DirectionUndefined"}`, true},
+		{"direction unknown", `{"Facing":"DirectionUp"}`, false},
+		{"direction out of range", `{"Facing":7}`, false},
+		{"diagonal unknown", `{"Corner":"DirectionPosQ"}`, false},
+		{"diagonal negative", `{"Corner":-1}`, false},
+		{"key", `{"Tiles":{"1;2":"sand"}}`, false},
+	}
+	for _, params := range plan {
+		var c config
+		err := json.Unmarshal([]byte(params.input), &c)
+		if params.valid && err != nil {
+			t.Errorf("%s: unexpected error %v", params.name, err)
+		}
+		if !params.valid && err == nil {
+			t.Errorf("%s: expected an error, got %+v", params.name, c)
+		}
+	}
+
+	for d := DirectionPosQ; d <= DirectionUndefined; d++ {
+		if result, err := ParseDirection(d.String()); err != nil || result != d {
+			t.Errorf("%s: parsed as %s, %v", d, result, err)
+		}
+	}
+	for d := DiagonalPosQ; d <= DiagonalUndefined; d++ {
+		if result, err := ParseDiagonal(d.String()); err != nil || result != d {
+			t.Errorf("%s: parsed as %s, %v", d, result, err)
+		}
+	}
+}
@@ -0,0 +1,556 @@
+package hexagolang
+
+// Hexagonal maps from the Tiled map editor as described in
+// https://doc.mapeditor.org/en/stable/reference/tmx-map-format/
+// and
+// https://doc.mapeditor.org/en/stable/reference/json-map-format/
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// TiledMap is a hexagonal Tiled map with its tile layers keyed by hex.
+// The stagger axis and index become the offset layout, staggering rows is
+// OrientationPointy and staggering columns is OrientationFlat.
+type TiledMap struct {
+	Width, Height         int            // Width and Height of the map in tiles.
+	TileWidth, TileHeight int            // TileWidth and TileHeight of a tile in pixels.
+	HexSideLength         int            // HexSideLength of the sides along the stagger axis in pixels.
+	Offset                OffsetEnum     // Offset is the stagger axis and index of the map.
+	Tilesets              []TiledTileset // Tilesets used by the map.
+	Layers                []TiledLayer   // Layers are the tile layers, other kinds of layer are skipped.
+}
+
+// TiledTileset is a tileset used by the map, either a reference to a tileset file or embedded in the map.
+// The fields after Source describe an embedded tileset and are written in both
+// formats. Anything else an embedded tileset holds, like the properties of its
+// tiles, is only written back in the format it was read from.
+type TiledTileset struct {
+	FirstGID uint32
+	Source   string // Source is the tileset file, empty when the tileset is embedded.
+
+	Name                  string
+	TileWidth, TileHeight int        // TileWidth and TileHeight of a tile in pixels.
+	Spacing, Margin       int        // Spacing between the tiles and Margin around them in the image, in pixels.
+	TileCount, Columns    int        // TileCount is the number of tiles, laid out in Columns in the image.
+	Image                 TiledImage // Image holding the tiles, empty for a collection of images.
+
+	tmxAttrs    []xml.Attr      // tmxAttrs are the other attributes of a tileset embedded in TMX.
+	tmxElements []tmxElement    // tmxElements are the other elements of a tileset embedded in TMX.
+	jsonRaw     json.RawMessage // jsonRaw is the object of a tileset embedded in JSON.
+}
+
+// TiledImage is the image file of a tileset.
+type TiledImage struct {
+	Source        string
+	Width, Height int // Width and Height of the image in pixels.
+}
+
+// TiledLayer is a tile layer holding the global tile id of each hex, empty tiles are left out.
+type TiledLayer struct {
+	Name  string
+	Tiles map[H]uint32
+}
+
+// Orientation returns the orientation matching the stagger axis.
+func (m TiledMap) Orientation() Orientation {
+	if m.Offset == OffsetOddQ || m.Offset == OffsetEvenQ {
+		return OrientationFlat
+	}
+	return OrientationPointy
+}
+
+// Layout returns the layout centering hexagons where Tiled centers the tiles.
+// The radius may differ on X and Y, the outlines match the tiles when
+// HexSideLength is half the tile size along the stagger axis.
+func (m TiledMap) Layout() Layout {
+	tw, th, side := float64(m.TileWidth), float64(m.TileHeight), float64(m.HexSideLength)
+	origin := F{tw / 2, th / 2}
+	switch m.Offset {
+	case OffsetEvenR:
+		origin.X += tw / 2
+	case OffsetEvenQ:
+		origin.Y += th / 2
+	}
+	if m.Orientation() == OrientationFlat {
+		return MakeLayout(F{(tw + side) / 3, th / math.Sqrt(3)}, origin, OrientationFlat)
+	}
+	return MakeLayout(F{tw / math.Sqrt(3), (th + side) / 3}, origin, OrientationPointy)
+}
+
+// ReadTiledTMX reads a hexagonal map in the TMX format.
+func ReadTiledTMX(r io.Reader) (TiledMap, error) {
+	var in tmxMap
+	if err := xml.NewDecoder(r).Decode(&in); err != nil {
+		return TiledMap{}, err
+	}
+	m, err := makeTiledMap(in.tiledHeader, in.Infinite != 0)
+	if err != nil {
+		return TiledMap{}, err
+	}
+	for _, ts := range in.Tilesets {
+		m.Tilesets = append(m.Tilesets, ts.tileset())
+	}
+	for _, layer := range in.Layers {
+		var gids []uint32
+		if layer.Data.Encoding == "" {
+			for _, tile := range layer.Data.Tiles {
+				gids = append(gids, tile.GID)
+			}
+		} else {
+			gids, err = tiledDecode(layer.Data.Encoding, layer.Data.Compression, layer.Data.Text)
+			if err != nil {
+				return TiledMap{}, fmt.Errorf("hexagolang: layer %q: %v", layer.Name, err)
+			}
+		}
+		if err := m.addLayer(layer.Name, gids); err != nil {
+			return TiledMap{}, err
+		}
+	}
+	return m, nil
+}
+
+// WriteTMX writes the map in the TMX format with CSV tile data.
+func (m TiledMap) WriteTMX(w io.Writer) error {
+	out := tmxMap{Version: tiledVersion, tiledHeader: m.header()}
+	for _, ts := range m.Tilesets {
+		out.Tilesets = append(out.Tilesets, makeTMXTileset(ts))
+	}
+	for k, layer := range m.Layers {
+		gids, err := m.layerData(layer)
+		if err != nil {
+			return err
+		}
+		// Tiled writes a line per row, each ending in a comma but the last.
+		var text strings.Builder
+		for j, gid := range gids {
+			text.WriteString(strconv.FormatUint(uint64(gid), 10))
+			if j+1 < len(gids) {
+				text.WriteByte(',')
+			}
+			if (j+1)%m.Width == 0 {
+				text.WriteByte('\n')
+			}
+		}
+		out.Layers = append(out.Layers, tmxLayer{
+			ID: k + 1, Name: layer.Name, Width: m.Width, Height: m.Height,
+			Data: tmxData{Encoding: "csv", Text: "\n" + text.String()},
+		})
+	}
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", " ")
+	if err := enc.Encode(out); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// ReadTiledJSON reads a hexagonal map in the Tiled JSON format.
+func ReadTiledJSON(r io.Reader) (TiledMap, error) {
+	var in jsonMap
+	if err := json.NewDecoder(r).Decode(&in); err != nil {
+		return TiledMap{}, err
+	}
+	m, err := makeTiledMap(in.tiledHeader, in.Infinite)
+	if err != nil {
+		return TiledMap{}, err
+	}
+	for _, raw := range in.Tilesets {
+		var ts jsonTileset
+		if err := json.Unmarshal(raw, &ts); err != nil {
+			return TiledMap{}, err
+		}
+		tileset := ts.tileset()
+		if ts.Source == "" {
+			tileset.jsonRaw = raw
+		}
+		m.Tilesets = append(m.Tilesets, tileset)
+	}
+	for _, layer := range in.Layers {
+		if layer.Type != "tilelayer" {
+			continue
+		}
+		var gids []uint32
+		if layer.Encoding == "base64" {
+			var text string
+			if err := json.Unmarshal(layer.Data, &text); err != nil {
+				return TiledMap{}, fmt.Errorf("hexagolang: layer %q: %v", layer.Name, err)
+			}
+			gids, err = tiledDecode(layer.Encoding, layer.Compression, text)
+		} else {
+			err = json.Unmarshal(layer.Data, &gids)
+		}
+		if err != nil {
+			return TiledMap{}, fmt.Errorf("hexagolang: layer %q: %v", layer.Name, err)
+		}
+		if err := m.addLayer(layer.Name, gids); err != nil {
+			return TiledMap{}, err
+		}
+	}
+	return m, nil
+}
+
+// WriteJSON writes the map in the Tiled JSON format.
+func (m TiledMap) WriteJSON(w io.Writer) error {
+	out := jsonMap{Version: tiledVersion, tiledHeader: m.header(), Type: "map", Tilesets: []json.RawMessage{}, Layers: []jsonLayer{}}
+	for _, ts := range m.Tilesets {
+		raw, err := makeJSONTileset(ts)
+		if err != nil {
+			return err
+		}
+		out.Tilesets = append(out.Tilesets, raw)
+	}
+	for k, layer := range m.Layers {
+		gids, err := m.layerData(layer)
+		if err != nil {
+			return err
+		}
+		data, err := json.Marshal(gids)
+		if err != nil {
+			return err
+		}
+		out.Layers = append(out.Layers, jsonLayer{
+			Type: "tilelayer", ID: k + 1, Name: layer.Name, Width: m.Width, Height: m.Height,
+			Opacity: 1, Visible: true, Data: data,
+		})
+	}
+	return json.NewEncoder(w).Encode(out)
+}
+
+// makeTiledMap checks the header describes a map this package can read.
+func makeTiledMap(h tiledHeader, infinite bool) (TiledMap, error) {
+	if h.Orientation != "hexagonal" {
+		return TiledMap{}, fmt.Errorf("hexagolang: %q maps aren't hexagonal", h.Orientation)
+	}
+	if infinite {
+		return TiledMap{}, fmt.Errorf("hexagolang: infinite maps aren't supported")
+	}
+	offset := OffsetUndefined
+	switch h.StaggerAxis + h.StaggerIndex {
+	case "yodd":
+		offset = OffsetOddR
+	case "yeven":
+		offset = OffsetEvenR
+	case "xodd":
+		offset = OffsetOddQ
+	case "xeven":
+		offset = OffsetEvenQ
+	default:
+		return TiledMap{}, fmt.Errorf("hexagolang: invalid stagger axis %q and index %q", h.StaggerAxis, h.StaggerIndex)
+	}
+	return TiledMap{
+		Width: h.Width, Height: h.Height,
+		TileWidth: h.TileWidth, TileHeight: h.TileHeight,
+		HexSideLength: h.HexSideLength,
+		Offset:        offset,
+	}, nil
+}
+
+// header returns the attributes shared by both formats.
+func (m TiledMap) header() tiledHeader {
+	axis, index := "y", "odd"
+	switch m.Offset {
+	case OffsetEvenR:
+		index = "even"
+	case OffsetOddQ:
+		axis = "x"
+	case OffsetEvenQ:
+		axis, index = "x", "even"
+	}
+	return tiledHeader{
+		Orientation: "hexagonal", RenderOrder: "right-down",
+		Width: m.Width, Height: m.Height,
+		TileWidth: m.TileWidth, TileHeight: m.TileHeight,
+		HexSideLength: m.HexSideLength,
+		StaggerAxis:   axis, StaggerIndex: index,
+		NextLayerID: len(m.Layers) + 1, NextObjectID: 1,
+	}
+}
+
+// addLayer keys the tiles of a layer, given row by row, by hex.
+func (m *TiledMap) addLayer(name string, gids []uint32) error {
+	if len(gids) != m.Width*m.Height {
+		return fmt.Errorf("hexagolang: layer %q: expected %d tiles, got %d", name, m.Width*m.Height, len(gids))
+	}
+	layer := TiledLayer{Name: name, Tiles: make(map[H]uint32)}
+	for k, gid := range gids {
+		if gid != 0 {
+			layer.Tiles[Offset{Col: k % m.Width, Row: k / m.Width}.Hex(m.Offset)] = gid
+		}
+	}
+	m.Layers = append(m.Layers, layer)
+	return nil
+}
+
+// layerData returns the tiles of a layer row by row.
+func (m TiledMap) layerData(layer TiledLayer) ([]uint32, error) {
+	gids := make([]uint32, m.Width*m.Height)
+	for h, gid := range layer.Tiles {
+		o := h.Offset(m.Offset)
+		if o.Col < 0 || o.Col >= m.Width || o.Row < 0 || o.Row >= m.Height {
+			return nil, fmt.Errorf("hexagolang: layer %q: %+v is outside the map", layer.Name, h)
+		}
+		gids[o.Row*m.Width+o.Col] = gid
+	}
+	return gids, nil
+}
+
+// tiledVersion is the version of the formats written.
+const tiledVersion = "1.10"
+
+// tiledDecode reads encoded tile data, base64 may be compressed with zlib or gzip.
+func tiledDecode(encoding, compression, text string) ([]uint32, error) {
+	switch encoding {
+	case "csv":
+		var gids []uint32
+		for _, field := range strings.Split(text, ",") {
+			gid, err := strconv.ParseUint(strings.TrimSpace(field), 10, 32)
+			if err != nil {
+				return nil, err
+			}
+			gids = append(gids, uint32(gid))
+		}
+		return gids, nil
+	case "base64":
+		raw, err := base64.StdEncoding.DecodeString(strings.TrimSpace(text))
+		if err != nil {
+			return nil, err
+		}
+		var r io.Reader = bytes.NewReader(raw)
+		switch compression {
+		case "":
+		case "zlib":
+			r, err = zlib.NewReader(r)
+		case "gzip":
+			r, err = gzip.NewReader(r)
+		default:
+			return nil, fmt.Errorf("unsupported compression %q", compression)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if raw, err = ioutil.ReadAll(r); err != nil {
+			return nil, err
+		}
+		if len(raw)%4 != 0 {
+			return nil, fmt.Errorf("tile data isn't a whole number of tiles")
+		}
+		gids := make([]uint32, len(raw)/4)
+		for k := range gids {
+			gids[k] = binary.LittleEndian.Uint32(raw[4*k:])
+		}
+		return gids, nil
+	}
+	return nil, fmt.Errorf("unsupported encoding %q", encoding)
+}
+
+// tiledHeader holds the map attributes shared by the TMX and JSON formats.
+type tiledHeader struct {
+	Orientation   string `xml:"orientation,attr" json:"orientation"`
+	RenderOrder   string `xml:"renderorder,attr" json:"renderorder"`
+	Width         int    `xml:"width,attr" json:"width"`
+	Height        int    `xml:"height,attr" json:"height"`
+	TileWidth     int    `xml:"tilewidth,attr" json:"tilewidth"`
+	TileHeight    int    `xml:"tileheight,attr" json:"tileheight"`
+	HexSideLength int    `xml:"hexsidelength,attr" json:"hexsidelength"`
+	StaggerAxis   string `xml:"staggeraxis,attr" json:"staggeraxis"`
+	StaggerIndex  string `xml:"staggerindex,attr" json:"staggerindex"`
+	NextLayerID   int    `xml:"nextlayerid,attr" json:"nextlayerid"`
+	NextObjectID  int    `xml:"nextobjectid,attr" json:"nextobjectid"`
+}
+
+type tmxMap struct {
+	XMLName  xml.Name `xml:"map"`
+	Version  string   `xml:"version,attr"`
+	Infinite int      `xml:"infinite,attr"`
+	tiledHeader
+	Tilesets []tmxTileset `xml:"tileset"`
+	Layers   []tmxLayer   `xml:"layer"`
+}
+
+type tmxTileset struct {
+	FirstGID   uint32       `xml:"firstgid,attr"`
+	Source     string       `xml:"source,attr,omitempty"`
+	Name       string       `xml:"name,attr,omitempty"`
+	TileWidth  int          `xml:"tilewidth,attr,omitempty"`
+	TileHeight int          `xml:"tileheight,attr,omitempty"`
+	Spacing    int          `xml:"spacing,attr,omitempty"`
+	Margin     int          `xml:"margin,attr,omitempty"`
+	TileCount  int          `xml:"tilecount,attr,omitempty"`
+	Columns    int          `xml:"columns,attr,omitempty"`
+	Attrs      []xml.Attr   `xml:",any,attr"`
+	Image      *tmxImage    `xml:"image"`
+	Elements   []tmxElement `xml:",any"`
+}
+
+// makeTMXTileset returns the tileset as written in TMX.
+func makeTMXTileset(ts TiledTileset) tmxTileset {
+	if ts.Source != "" {
+		return tmxTileset{FirstGID: ts.FirstGID, Source: ts.Source}
+	}
+	out := tmxTileset{
+		FirstGID: ts.FirstGID, Name: ts.Name,
+		TileWidth: ts.TileWidth, TileHeight: ts.TileHeight,
+		Spacing: ts.Spacing, Margin: ts.Margin,
+		TileCount: ts.TileCount, Columns: ts.Columns,
+		Attrs: ts.tmxAttrs, Elements: ts.tmxElements,
+	}
+	if ts.Image != (TiledImage{}) {
+		out.Image = &tmxImage{Source: ts.Image.Source, Width: ts.Image.Width, Height: ts.Image.Height}
+	}
+	return out
+}
+
+// tileset returns the tileset read from TMX.
+func (ts tmxTileset) tileset() TiledTileset {
+	if ts.Source != "" {
+		return TiledTileset{FirstGID: ts.FirstGID, Source: ts.Source}
+	}
+	result := TiledTileset{
+		FirstGID: ts.FirstGID, Name: ts.Name,
+		TileWidth: ts.TileWidth, TileHeight: ts.TileHeight,
+		Spacing: ts.Spacing, Margin: ts.Margin,
+		TileCount: ts.TileCount, Columns: ts.Columns,
+		tmxAttrs: ts.Attrs, tmxElements: ts.Elements,
+	}
+	if ts.Image != nil {
+		result.Image = TiledImage{Source: ts.Image.Source, Width: ts.Image.Width, Height: ts.Image.Height}
+	}
+	return result
+}
+
+type tmxImage struct {
+	Source string `xml:"source,attr"`
+	Width  int    `xml:"width,attr,omitempty"`
+	Height int    `xml:"height,attr,omitempty"`
+}
+
+// tmxElement is an element kept as read.
+type tmxElement struct {
+	XMLName xml.Name
+	Attrs   []xml.Attr `xml:",any,attr"`
+	Inner   string     `xml:",innerxml"`
+}
+
+type tmxLayer struct {
+	ID     int     `xml:"id,attr"`
+	Name   string  `xml:"name,attr"`
+	Width  int     `xml:"width,attr"`
+	Height int     `xml:"height,attr"`
+	Data   tmxData `xml:"data"`
+}
+
+type tmxData struct {
+	Encoding    string    `xml:"encoding,attr,omitempty"`
+	Compression string    `xml:"compression,attr,omitempty"`
+	Text        string    `xml:",innerxml"`
+	Tiles       []tmxTile `xml:"tile"`
+}
+
+type tmxTile struct {
+	GID uint32 `xml:"gid,attr"`
+}
+
+type jsonMap struct {
+	Version  interface{} `json:"version"` // Version was a number in older files.
+	Infinite bool        `json:"infinite"`
+	tiledHeader
+	Type     string            `json:"type"`
+	Tilesets []json.RawMessage `json:"tilesets"`
+	Layers   []jsonLayer       `json:"layers"`
+}
+
+type jsonTileset struct {
+	FirstGID    uint32 `json:"firstgid"`
+	Source      string `json:"source,omitempty"`
+	Name        string `json:"name,omitempty"`
+	TileWidth   int    `json:"tilewidth,omitempty"`
+	TileHeight  int    `json:"tileheight,omitempty"`
+	Spacing     int    `json:"spacing,omitempty"`
+	Margin      int    `json:"margin,omitempty"`
+	TileCount   int    `json:"tilecount,omitempty"`
+	Columns     int    `json:"columns,omitempty"`
+	Image       string `json:"image,omitempty"`
+	ImageWidth  int    `json:"imagewidth,omitempty"`
+	ImageHeight int    `json:"imageheight,omitempty"`
+}
+
+// jsonTilesetKeys are the keys of jsonTileset, replaced in the tilesets kept as read.
+var jsonTilesetKeys = []string{
+	"firstgid", "source", "name", "tilewidth", "tileheight", "spacing", "margin",
+	"tilecount", "columns", "image", "imagewidth", "imageheight",
+}
+
+// makeJSONTileset returns the tileset as written in JSON.
+func makeJSONTileset(ts TiledTileset) (json.RawMessage, error) {
+	if ts.Source != "" {
+		return json.Marshal(jsonTileset{FirstGID: ts.FirstGID, Source: ts.Source})
+	}
+	known, err := json.Marshal(jsonTileset{
+		FirstGID: ts.FirstGID, Name: ts.Name,
+		TileWidth: ts.TileWidth, TileHeight: ts.TileHeight,
+		Spacing: ts.Spacing, Margin: ts.Margin,
+		TileCount: ts.TileCount, Columns: ts.Columns,
+		Image: ts.Image.Source, ImageWidth: ts.Image.Width, ImageHeight: ts.Image.Height,
+	})
+	if err != nil || ts.jsonRaw == nil {
+		return known, err
+	}
+	// Keep everything else read with the tileset.
+	fields := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(ts.jsonRaw, &fields); err != nil {
+		return nil, err
+	}
+	for _, key := range jsonTilesetKeys {
+		delete(fields, key)
+	}
+	if err := json.Unmarshal(known, &fields); err != nil {
+		return nil, err
+	}
+	return json.Marshal(fields)
+}
+
+// tileset returns the tileset read from JSON.
+func (ts jsonTileset) tileset() TiledTileset {
+	if ts.Source != "" {
+		return TiledTileset{FirstGID: ts.FirstGID, Source: ts.Source}
+	}
+	return TiledTileset{
+		FirstGID: ts.FirstGID, Name: ts.Name,
+		TileWidth: ts.TileWidth, TileHeight: ts.TileHeight,
+		Spacing: ts.Spacing, Margin: ts.Margin,
+		TileCount: ts.TileCount, Columns: ts.Columns,
+		Image: TiledImage{Source: ts.Image, Width: ts.ImageWidth, Height: ts.ImageHeight},
+	}
+}
+
+type jsonLayer struct {
+	Type        string          `json:"type"`
+	ID          int             `json:"id"`
+	Name        string          `json:"name"`
+	Width       int             `json:"width"`
+	Height      int             `json:"height"`
+	X           int             `json:"x"`
+	Y           int             `json:"y"`
+	Opacity     float64         `json:"opacity"`
+	Visible     bool            `json:"visible"`
+	Encoding    string          `json:"encoding,omitempty"`
+	Compression string          `json:"compression,omitempty"`
+	Data        json.RawMessage `json:"data"`
+}
@@ -0,0 +1,218 @@
+package hexagolang
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/base64"
+	"encoding/binary"
+	"io"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const tiledTMX = `<?xml version="1.0" encoding="UTF-8"?>
+<map version="1.10" tiledversion="1.10.2" orientation="hexagonal" renderorder="right-down" width="3" height="2" tilewidth="28" tileheight="32" infinite="0" hexsidelength="16" staggeraxis="y" staggerindex="odd" nextlayerid="4" nextobjectid="1">
+ <tileset firstgid="1" source="terrain.tsx"/>
+ <tileset firstgid="100" name="embedded" tilewidth="28" tileheight="32" tilecount="4" columns="2">
+  <tileoffset x="2" y="4"/>
+  <image source="hexes.png" width="56" height="64"/>
+ </tileset>
+ <layer id="1" name="ground" width="3" height="2">
+  <data encoding="csv">
+1,2,3,
+0,5,101
+</data>
+ </layer>
+ <objectgroup id="2" name="spawns"/>
+ <layer id="3" name="items" width="3" height="2">
+  <data encoding="base64" compression="zlib">%s</data>
+ </layer>
+</map>
+`
+
+// I need to exchange maps with the Tiled editor.
+// Rational, level designers build hexagonal maps in Tiled.
+func TestTiledRead(t *testing.T) {
+	var raw bytes.Buffer
+	z := zlib.NewWriter(&raw)
+	binary.Write(z, binary.LittleEndian, []uint32{0, 0, 7, 8, 0, 0})
+	z.Close()
+	text := strings.Replace(tiledTMX, "%s", base64.StdEncoding.EncodeToString(raw.Bytes()), 1)
+
+	m, err := ReadTiledTMX(strings.NewReader(text))
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	embedded := TiledTileset{
+		FirstGID: 100, Name: "embedded", TileWidth: 28, TileHeight: 32, TileCount: 4, Columns: 2,
+		Image: TiledImage{Source: "hexes.png", Width: 56, Height: 64},
+	}
+	expected := TiledMap{
+		Width: 3, Height: 2, TileWidth: 28, TileHeight: 32, HexSideLength: 16,
+		Offset:   OffsetOddR,
+		Tilesets: []TiledTileset{{FirstGID: 1, Source: "terrain.tsx"}, embedded},
+		Layers: []TiledLayer{
+			{"ground", map[H]uint32{{0, 0}: 1, {1, 0}: 2, {2, 0}: 3, {1, 1}: 5, {2, 1}: 101}},
+			{"items", map[H]uint32{{2, 0}: 7, {0, 1}: 8}},
+		},
+	}
+	// exported leaves out what is only kept for writing the format a tileset was read from.
+	exported := func(m TiledMap) TiledMap {
+		m.Tilesets = append([]TiledTileset{}, m.Tilesets...)
+		for k, ts := range m.Tilesets {
+			ts.tmxAttrs, ts.tmxElements, ts.jsonRaw = nil, nil, nil
+			m.Tilesets[k] = ts
+		}
+		return m
+	}
+	if !reflect.DeepEqual(exported(m), expected) {
+		t.Errorf("read: expected %+v, got %+v", expected, m)
+	}
+	if m.Orientation() != OrientationPointy {
+		t.Errorf("staggering rows should be pointy")
+	}
+
+	// Writing either format and reading it back keeps the map and its embedded tilesets.
+	var tmx, js bytes.Buffer
+	if err := m.WriteTMX(&tmx); err != nil {
+		t.Fatalf("write tmx: %v", err)
+	}
+	if !strings.Contains(tmx.String(), `<tileoffset x="2" y="4"></tileoffset>`) {
+		t.Errorf("tmx: expected the tile offset to be kept, got %s", tmx.String())
+	}
+	if result, err := ReadTiledTMX(&tmx); err != nil || !reflect.DeepEqual(result, m) {
+		t.Errorf("tmx: expected %+v, got %+v, %v", m, result, err)
+	}
+	if err := m.WriteJSON(&js); err != nil {
+		t.Fatalf("write json: %v", err)
+	}
+	if !strings.Contains(js.String(), `{"firstgid":100,"name":"embedded","tilewidth":28,"tileheight":32,"tilecount":4,"columns":2,"image":"hexes.png","imagewidth":56,"imageheight":64}`) {
+		t.Errorf("json: expected the embedded tileset, got %s", js.String())
+	}
+	result, err := ReadTiledJSON(&js)
+	if err != nil {
+		t.Fatalf("read json: %v", err)
+	}
+	if !reflect.DeepEqual(exported(result), expected) {
+		t.Errorf("json: expected %+v, got %+v", expected, result)
+	}
+
+	// A tileset read from JSON keeps its other fields in JSON and can be written to TMX.
+	js.Reset()
+	m.Tilesets[1] = result.Tilesets[1]
+	m.Tilesets[1].jsonRaw = []byte(`{"firstgid":100,"name":"old","tileoffset":{"x":2,"y":4}}`)
+	m.Tilesets[1].FirstGID = 50
+	if err := m.WriteJSON(&js); err != nil {
+		t.Fatalf("write json: %v", err)
+	}
+	if !strings.Contains(js.String(), `"firstgid":50,`) || !strings.Contains(js.String(), `"name":"embedded",`) ||
+		!strings.Contains(js.String(), `"tileoffset":{"x":2,"y":4}`) {
+		t.Errorf("json: expected the embedded tileset, got %s", js.String())
+	}
+	tmx.Reset()
+	if err := m.WriteTMX(&tmx); err != nil {
+		t.Fatalf("write tmx: %v", err)
+	}
+	if result, err := ReadTiledTMX(&tmx); err != nil || !reflect.DeepEqual(result.Tilesets[1], exported(m).Tilesets[1]) {
+		t.Errorf("tmx: expected %+v, got %+v, %v", m.Tilesets[1], result.Tilesets[1], err)
+	}
+
+	// Callers can embed tilesets of their own.
+	m.Tilesets[1] = TiledTileset{
+		FirstGID: 100, Name: "built", TileWidth: 28, TileHeight: 32, Spacing: 1, Margin: 2, TileCount: 6, Columns: 3,
+		Image: TiledImage{Source: "built.png", Width: 88, Height: 70},
+	}
+	for name, format := range map[string]struct {
+		write func(TiledMap, io.Writer) error
+		read  func(io.Reader) (TiledMap, error)
+	}{
+		"tmx":  {TiledMap.WriteTMX, ReadTiledTMX},
+		"json": {TiledMap.WriteJSON, ReadTiledJSON},
+	} {
+		var out bytes.Buffer
+		if err := format.write(m, &out); err != nil {
+			t.Fatalf("%s: write: %v", name, err)
+		}
+		if result, err := format.read(&out); err != nil || !reflect.DeepEqual(exported(result), m) {
+			t.Errorf("%s: expected %+v, got %+v, %v", name, m, result, err)
+		}
+	}
+
+	plan := []struct {
+		name  string
+		input string
+	}{
+		{"orthogonal", `{"orientation":"orthogonal","width":1,"height":1,"layers":[]}`},
+		{"infinite", `{"orientation":"hexagonal","infinite":true,"staggeraxis":"x","staggerindex":"odd","layers":[]}`},
+		{"stagger", `{"orientation":"hexagonal","staggeraxis":"z","staggerindex":"odd","layers":[]}`},
+		{"short layer", `{"orientation":"hexagonal","width":2,"height":1,"staggeraxis":"x","staggerindex":"odd",
+			"layers":[{"type":"tilelayer","name":"a","data":[1]}]}`},
+		{"compression", `{"orientation":"hexagonal","width":1,"height":1,"staggeraxis":"x","staggerindex":"odd",
+			"layers":[{"type":"tilelayer","name":"a","encoding":"base64","compression":"zstd","data":"AQAAAA=="}]}`},
+	}
+	for _, params := range plan {
+		if _, err := ReadTiledJSON(strings.NewReader(params.input)); err == nil {
+			t.Errorf("%s: expected an error", params.name)
+		}
+	}
+	older := `{"version":1.2,"orientation":"hexagonal","width":1,"height":1,"staggeraxis":"x","staggerindex":"even",
+		"layers":[{"type":"tilelayer","name":"a","encoding":"base64","data":"AQAAAA=="}]}`
+	if result, err := ReadTiledJSON(strings.NewReader(older)); err != nil || result.Layers[0].Tiles[H{0, 0}] != 1 {
+		t.Errorf("older: expected one tile, got %+v, %v", result, err)
+	}
+
+	m.Layers[0].Tiles[H{-1, 0}] = 4
+	if err := m.WriteTMX(&tmx); err == nil {
+		t.Errorf("expected an error for a tile outside the map")
+	}
+}
+
+// I need hexagons drawn where Tiled draws the tiles.
+// Rational, sprites and objects placed in Tiled must line up with the grid.
+func TestTiledLayout(t *testing.T) {
+	for _, o := range []OffsetEnum{OffsetOddR, OffsetEvenR, OffsetOddQ, OffsetEvenQ} {
+		m := TiledMap{Width: 5, Height: 4, TileWidth: 30, TileHeight: 28, HexSideLength: 14, Offset: o}
+		l := m.Layout()
+		tw, th, side := 30., 28., 14.
+		for row := 0; row < m.Height; row++ {
+			for col := 0; col < m.Width; col++ {
+				// Tiled places the corner of each tile and shifts the staggered rows or columns.
+				var x, y float64
+				switch o {
+				case OffsetOddR, OffsetEvenR:
+					x, y = float64(col)*tw, float64(row)*(th+side)/2
+					if (row&1 == 1) == (o == OffsetOddR) {
+						x += tw / 2
+					}
+				default:
+					x, y = float64(col)*(tw+side)/2, float64(row)*th
+					if (col&1 == 1) == (o == OffsetOddQ) {
+						y += th / 2
+					}
+				}
+				expected := F{x + tw/2, y + th/2}
+				result := l.CenterFor(Offset{col, row}.Hex(o))
+				if math.Abs(result.X-expected.X) > 1e-9 || math.Abs(result.Y-expected.Y) > 1e-9 {
+					t.Errorf("%s: %d,%d: expected %+v, got %+v", o, col, row, expected, result)
+				}
+			}
+		}
+		// With sides half the tile along the stagger axis the hexagon fills the tile.
+		if o == OffsetOddQ || o == OffsetEvenQ {
+			m.TileWidth = 28
+			tw = 28
+			l = m.Layout()
+		}
+		h := Offset{1, 1}.Hex(o)
+		min, max := l.CenterFor(h), l.CenterFor(h)
+		for _, c := range l.corners(h) {
+			min = F{math.Min(min.X, c.X), math.Min(min.Y, c.Y)}
+			max = F{math.Max(max.X, c.X), math.Max(max.Y, c.Y)}
+		}
+		if size := max.Subtract(min); math.Abs(size.X-tw) > 1e-9 || math.Abs(size.Y-th) > 1e-9 {
+			t.Errorf("%s: expected a %vx%v hexagon, got %+v", o, tw, th, size)
+		}
+	}
+}
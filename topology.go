@@ -0,0 +1,247 @@
+package hexagolang
+
+// Wraparound maps as described in
+// https://www.redblobgames.com/grids/hexagons/#wraparound
+
+import (
+	"fmt"
+)
+
+// Topology is the shape of the space the hexagons live in.
+// Every method returns hexagons normalized into the wrapped domain.
+type Topology interface {
+	// Normalize returns the hex inside the domain that h wraps onto.
+	Normalize(h H) H
+	// Subtract returns the shortest delta from b to a, crossing seams when shorter. (a - b)
+	Subtract(a, b H) D
+	// Neighbor one step in a specific direction.
+	Neighbor(h H, d DirectionEnum) H
+	// Length returns the manhattan distance between two hexagons.
+	Length(a, b H) int
+	// Line gets the hexagons on the shortest line between two hex.
+	Line(a, b H) []H
+	// Range returns all points in a distance from a point.
+	Range(h H, rad int) map[H]bool
+	// Ring returns the ring of hex points specific manhattan distance from h.
+	Ring(h H, rad int) map[H]bool
+}
+
+// Plane is the default unbounded grid, nothing wraps.
+type Plane struct{}
+
+// Normalize returns h.
+func (Plane) Normalize(h H) H { return h }
+
+// Subtract is Subtract(a, b).
+func (Plane) Subtract(a, b H) D { return Subtract(a, b) }
+
+// Neighbor is h.Neighbor(d).
+func (Plane) Neighbor(h H, d DirectionEnum) H { return h.Neighbor(d) }
+
+// Length is Length(Subtract(a, b)).
+func (Plane) Length(a, b H) int { return Length(Subtract(a, b)) }
+
+// Line is Line(a, b).
+func (Plane) Line(a, b H) []H { return Line(a, b) }
+
+// Range is Range(h, rad).
+func (Plane) Range(h H, rad int) map[H]bool { return Range(h, rad) }
+
+// Ring is Ring(h, rad).
+func (Plane) Ring(h H, rad int) map[H]bool { return Ring(h, rad) }
+
+// Torus is a rectangular map, Width columns by Height rows in offset coordinates,
+// that wraps from one side to the other. A Width or Height of 0 doesn't wrap that axis,
+// so Torus{Width: 80, Offset: OffsetOddR} is a world wrapping only horizontally.
+// The shoved axis must wrap after an even count to keep the stagger lined up:
+// Height must be even with the R layouts and Width must be even with the Q layouts.
+// The methods panic when the torus isn't Valid.
+type Torus struct {
+	Width, Height int
+	Offset        OffsetEnum
+}
+
+// MakeTorus returns a torus wrapping after width columns and height rows, failing when it isn't Valid.
+func MakeTorus(width, height int, o OffsetEnum) (Torus, error) {
+	t := Torus{Width: width, Height: height, Offset: o}
+	return t, t.Valid()
+}
+
+// Valid returns why the torus can't wrap, or nil when it can.
+func (t Torus) Valid() error {
+	switch {
+	case t.Offset < OffsetOddR || t.Offset >= OffsetUndefined:
+		return fmt.Errorf("hexagolang: torus offset %s isn't defined", t.Offset)
+	case t.Width < 0 || t.Height < 0:
+		return fmt.Errorf("hexagolang: torus size %dx%d is negative", t.Width, t.Height)
+	case (t.Offset == OffsetOddR || t.Offset == OffsetEvenR) && t.Height%2 != 0:
+		return fmt.Errorf("hexagolang: torus height %d must be even with %s", t.Height, t.Offset)
+	case (t.Offset == OffsetOddQ || t.Offset == OffsetEvenQ) && t.Width%2 != 0:
+		return fmt.Errorf("hexagolang: torus width %d must be even with %s", t.Width, t.Offset)
+	}
+	return nil
+}
+
+// Normalize returns the hex inside the rectangle that h wraps onto.
+func (t Torus) Normalize(h H) H {
+	if err := t.Valid(); err != nil {
+		panic(err)
+	}
+	c := h.Offset(t.Offset)
+	if t.Width > 0 {
+		c.Col = intMod(c.Col, t.Width)
+	}
+	if t.Height > 0 {
+		c.Row = intMod(c.Row, t.Height)
+	}
+	return c.Hex(t.Offset)
+}
+
+// Subtract returns the shortest delta from b to a, crossing seams when shorter. (a - b)
+func (t Torus) Subtract(a, b H) D {
+	origin := Offset{}.Hex(t.Offset)
+	across := Subtract(Offset{Col: t.Width}.Hex(t.Offset), origin)
+	down := Subtract(Offset{Row: t.Height}.Hex(t.Offset), origin)
+
+	delta := Subtract(t.Normalize(a), t.Normalize(b))
+	result := delta
+	for i := -1; i <= 1; i++ {
+		for j := -1; j <= 1; j++ {
+			d := D{
+				Q: delta.Q + across.Q*i + down.Q*j,
+				R: delta.R + across.R*i + down.R*j,
+				S: delta.S + across.S*i + down.S*j,
+			}
+			if Length(d) < Length(result) {
+				result = d
+			}
+		}
+	}
+	return result
+}
+
+// Neighbor one step in a specific direction.
+func (t Torus) Neighbor(h H, d DirectionEnum) H { return t.Normalize(h.Neighbor(d)) }
+
+// Length returns the manhattan distance between two hexagons.
+func (t Torus) Length(a, b H) int { return Length(t.Subtract(a, b)) }
+
+// Line gets the hexagons on the shortest line between two hex.
+func (t Torus) Line(a, b H) []H { return wrapLine(t, a, b) }
+
+// Range returns all points in a distance from a point.
+func (t Torus) Range(h H, rad int) map[H]bool { return wrapRange(t, h, rad) }
+
+// Ring returns the ring of hex points specific manhattan distance from h.
+func (t Torus) Ring(h H, rad int) map[H]bool { return wrapRing(t, h, rad) }
+
+// HexagonalWrap is a hexagon shaped map of Radius around H{0, 0} where leaving
+// one side enters the opposite side.
+// The methods panic when the map isn't Valid.
+type HexagonalWrap struct {
+	Radius int
+}
+
+// MakeHexagonalWrap returns a hexagonal wrap of radius, failing when it isn't Valid.
+func MakeHexagonalWrap(radius int) (HexagonalWrap, error) {
+	w := HexagonalWrap{Radius: radius}
+	return w, w.Valid()
+}
+
+// Valid returns why the map can't wrap, or nil when it can.
+func (w HexagonalWrap) Valid() error {
+	if w.Radius < 0 {
+		return fmt.Errorf("hexagolang: hexagonal wrap radius %d is negative", w.Radius)
+	}
+	return nil
+}
+
+// mirrors returns the centers of the copies of the map surrounding the original.
+func (w HexagonalWrap) mirrors() [6]D {
+	var result [6]D
+	m := D{2*w.Radius + 1, -w.Radius, -w.Radius - 1}
+	for k := range result {
+		result[k] = m
+		m = D{-m.R, -m.S, -m.Q}
+	}
+	return result
+}
+
+// Normalize returns the hex inside the hexagon that h wraps onto.
+func (w HexagonalWrap) Normalize(h H) H {
+	if err := w.Valid(); err != nil {
+		panic(err)
+	}
+	mirrors := w.mirrors()
+	for Length(h.Delta()) > w.Radius {
+		best := h
+		for _, m := range mirrors {
+			if moved := Add(h, Multiply(m, -1)); Length(moved.Delta()) < Length(best.Delta()) {
+				best = moved
+			}
+		}
+		h = best
+	}
+	return h
+}
+
+// Subtract returns the shortest delta from b to a, crossing seams when shorter. (a - b)
+func (w HexagonalWrap) Subtract(a, b H) D {
+	return w.Normalize(Subtract(a, b).Hex()).Delta()
+}
+
+// Neighbor one step in a specific direction.
+func (w HexagonalWrap) Neighbor(h H, d DirectionEnum) H { return w.Normalize(h.Neighbor(d)) }
+
+// Length returns the manhattan distance between two hexagons.
+func (w HexagonalWrap) Length(a, b H) int { return Length(w.Subtract(a, b)) }
+
+// Line gets the hexagons on the shortest line between two hex.
+func (w HexagonalWrap) Line(a, b H) []H { return wrapLine(w, a, b) }
+
+// Range returns all points in a distance from a point.
+func (w HexagonalWrap) Range(h H, rad int) map[H]bool { return wrapRange(w, h, rad) }
+
+// Ring returns the ring of hex points specific manhattan distance from h.
+func (w HexagonalWrap) Ring(h H, rad int) map[H]bool { return wrapRing(w, h, rad) }
+
+// wrapLine draws the line to the closest copy of b and folds it back into the domain.
+func wrapLine(t Topology, a, b H) []H {
+	a = t.Normalize(a)
+	results := make([]H, 0, t.Length(a, b)+2)
+	EachInLine(a, Add(a, t.Subtract(b, a)), func(h H) bool {
+		results = append(results, t.Normalize(h))
+		return true
+	})
+	return results
+}
+
+// wrapRange folds Range into the domain.
+func wrapRange(t Topology, h H, rad int) map[H]bool {
+	results := make(map[H]bool)
+	EachInRange(h, rad, func(k H) bool {
+		results[t.Normalize(k)] = true
+		return true
+	})
+	return results
+}
+
+// wrapRing folds Ring into the domain, dropping the hexagons a shorter way around brings closer.
+func wrapRing(t Topology, h H, rad int) map[H]bool {
+	results := make(map[H]bool)
+	EachInRing(h, rad, func(k H) bool {
+		if t.Length(h, k) == rad {
+			results[t.Normalize(k)] = true
+		}
+		return true
+	})
+	return results
+}
+
+func intMod(a, m int) int {
+	a %= m
+	if a < 0 {
+		a += m
+	}
+	return a
+}
@@ -0,0 +1,215 @@
+package hexagolang
+
+import (
+	"testing"
+)
+
+// I need the grid to wrap around from one side to the other.
+// Rational, strategy game worlds wrap horizontally like a globe.
+func TestTorus(t *testing.T) {
+	world := Torus{Width: 10, Offset: OffsetOddR}
+	plan := []struct {
+		a, b H
+		dist int
+	}{
+		{H{0, 0}, H{9, 0}, 1},
+		{H{0, 0}, H{5, 0}, 5},
+		{H{0, 0}, H{8, 0}, 2},
+		{H{0, 3}, H{8, 4}, 2},
+		{H{0, 0}, H{0, 30}, 30},
+	}
+	for tc, expected := range plan {
+		if result := world.Length(expected.a, expected.b); result != expected.dist {
+			t.Errorf("index %d: expected distance %d, got %d", tc, expected.dist, result)
+		}
+		if result := world.Length(expected.b, expected.a); result != expected.dist {
+			t.Errorf("index %d: expected reversed distance %d, got %d", tc, expected.dist, result)
+		}
+	}
+
+	if result := world.Normalize(H{10, 0}); result != (H{0, 0}) {
+		t.Errorf("expected H{10, 0} to wrap onto H{0, 0}, got %+v", result)
+	}
+	if result := world.Neighbor(H{9, 0}, DirectionPosQ); result != (H{0, 0}) {
+		t.Errorf("expected the PosQ neighbor of H{9, 0} to be H{0, 0}, got %+v", result)
+	}
+	line := world.Line(H{0, 0}, H{8, 0})
+	expected := []H{{0, 0}, {9, 0}, {8, 0}}
+	if len(line) != len(expected) {
+		t.Fatalf("expected line %+v, got %+v", expected, line)
+	}
+	for k := range line {
+		if line[k] != expected[k] {
+			t.Errorf("line index %d: expected %+v, got %+v", k, expected[k], line[k])
+		}
+	}
+	ring := world.Ring(H{0, 0}, 1)
+	if len(ring) != 6 || !ring[H{9, 0}] || !ring[H{9, 1}] {
+		t.Errorf("expected the ring to cross the seam, got %+v", ring)
+	}
+}
+
+// I need rings larger than half the map to keep their distance.
+// Rational, splash damage on a small world must not reach around and hit closer hexagons.
+func TestWrapRing(t *testing.T) {
+	for _, world := range []Topology{
+		Torus{Width: 6, Height: 6, Offset: OffsetOddR},
+		Torus{Width: 10, Offset: OffsetOddR},
+		HexagonalWrap{Radius: 3},
+	} {
+		for rad := 1; rad <= 8; rad++ {
+			ring := world.Ring(H{0, 0}, rad)
+			inside, outside := world.Range(H{0, 0}, rad-1), world.Range(H{0, 0}, rad)
+			inside[H{0, 0}] = true // Range leaves the center out at radius 0.
+			for h := range ring {
+				if dist := world.Length(H{0, 0}, h); dist != rad {
+					t.Errorf("%+v: radius %d: %+v is %d away", world, rad, h, dist)
+				}
+			}
+			for h := range outside {
+				if !inside[h] && !ring[h] {
+					t.Errorf("%+v: radius %d: %+v is missing", world, rad, h)
+				}
+			}
+		}
+	}
+}
+
+// I need the grid to wrap both ways.
+// Rational, small worlds wrap vertically too.
+func TestTorusWrapsBothAxes(t *testing.T) {
+	for _, world := range []Torus{
+		{Width: 8, Height: 6, Offset: OffsetOddR},
+		{Width: 6, Height: 5, Offset: OffsetEvenQ},
+	} {
+		var domain []H
+		for row := 0; row < world.Height; row++ {
+			for col := 0; col < world.Width; col++ {
+				domain = append(domain, Offset{col, row}.Hex(world.Offset))
+			}
+		}
+		for _, a := range domain {
+			if result := world.Normalize(a); result != a {
+				t.Errorf("%+v: %+v should already be normalized, got %+v", world, a, result)
+			}
+			for d := DirectionPosQ; d < DirectionUndefined; d++ {
+				if world.Length(a, world.Neighbor(a, d)) != 1 {
+					t.Errorf("%+v: neighbor %s of %+v isn't adjacent", world, d, a)
+				}
+			}
+			if ring := world.Ring(a, 2); len(ring) != 12 {
+				t.Errorf("%+v: expected a ring of 12 around %+v, got %d", world, a, len(ring))
+			}
+			for _, b := range domain {
+				dist := world.Length(a, b)
+				if dist != world.Length(b, a) || dist > Length(Subtract(a, b)) {
+					t.Errorf("%+v: bad distance %d between %+v and %+v", world, dist, a, b)
+				}
+				if line := world.Line(a, b); len(line) != dist+1 {
+					t.Errorf("%+v: line %+v to %+v should have %d steps, got %+v", world, a, b, dist+1, line)
+				}
+			}
+		}
+	}
+}
+
+// I need to know when a torus can't wrap.
+// Rational, an odd count on the shoved axis breaks the stagger and every distance with it.
+func TestTorusValid(t *testing.T) {
+	plan := []struct {
+		world Torus
+		valid bool
+	}{
+		{Torus{Width: 7, Height: 6, Offset: OffsetOddR}, true},
+		{Torus{Width: 7, Offset: OffsetEvenR}, true},
+		{Torus{Width: 6, Height: 5, Offset: OffsetEvenQ}, true},
+		{Torus{Width: 8, Height: 5, Offset: OffsetOddR}, false},
+		{Torus{Width: 5, Height: 8, Offset: OffsetOddQ}, false},
+		{Torus{Width: -2, Offset: OffsetOddR}, false},
+		{Torus{Width: 8, Height: 8, Offset: OffsetUndefined}, false},
+	}
+	for k, params := range plan {
+		if err := params.world.Valid(); (err == nil) != params.valid {
+			t.Errorf("index %d: expected valid %v, got %v", k, params.valid, err)
+		}
+		if _, err := MakeTorus(params.world.Width, params.world.Height, params.world.Offset); (err == nil) != params.valid {
+			t.Errorf("index %d: expected MakeTorus to succeed %v, got %v", k, params.valid, err)
+		}
+		func() {
+			defer func() {
+				if panicked := recover() != nil; panicked == params.valid {
+					t.Errorf("index %d: expected a panic %v, got %v", k, !params.valid, panicked)
+				}
+			}()
+			params.world.Subtract(H{0, 0}, H{3, 3})
+		}()
+	}
+}
+
+// I need to know when a hexagonal wrap can't wrap.
+// Rational, a negative radius has no hexagons to wrap onto.
+func TestHexagonalWrapValid(t *testing.T) {
+	for _, radius := range []int{-1, 0, 3} {
+		valid := radius >= 0
+		if _, err := MakeHexagonalWrap(radius); (err == nil) != valid {
+			t.Errorf("radius %d: expected MakeHexagonalWrap to succeed %v, got %v", radius, valid, err)
+		}
+		func() {
+			defer func() {
+				if panicked := recover() != nil; panicked == valid {
+					t.Errorf("radius %d: expected a panic %v, got %v", radius, !valid, panicked)
+				}
+			}()
+			HexagonalWrap{Radius: radius}.Normalize(H{4, -2})
+		}()
+	}
+}
+
+// I need a hexagon shaped map that wraps onto itself.
+// Rational, hexagonal wrap has no corners so every hex is treated the same.
+func TestHexagonalWrap(t *testing.T) {
+	world := HexagonalWrap{Radius: 3}
+	domain := Range(H{0, 0}, 3)
+	for h := range Range(H{2, -1}, 30) {
+		if result := world.Normalize(h); !domain[result] {
+			t.Errorf("%+v normalized outside the map to %+v", h, result)
+		}
+	}
+	if result := world.Neighbor(H{3, 0}, DirectionPosQ); result != (H{-3, 3}) {
+		t.Errorf("expected H{3, 0} to wrap onto H{-3, 3}, got %+v", result)
+	}
+	for a := range domain {
+		if ring := world.Ring(a, 1); len(ring) != 6 {
+			t.Errorf("expected 6 neighbors around %+v, got %+v", a, ring)
+		}
+		if area := world.Range(a, 3); len(area) != len(domain) {
+			t.Errorf("expected range 3 to cover the map from %+v, got %d", a, len(area))
+		}
+		for b := range domain {
+			dist := world.Length(a, b)
+			if dist > 3 || dist != world.Length(b, a) {
+				t.Errorf("bad distance %d between %+v and %+v", dist, a, b)
+			}
+			for _, h := range world.Line(a, b) {
+				if !domain[h] {
+					t.Errorf("line %+v to %+v left the map at %+v", a, b, h)
+				}
+			}
+		}
+	}
+}
+
+// I need the unwrapped grid to behave like the package functions.
+// Rational, Plane is the default for code written against Topology.
+func TestPlane(t *testing.T) {
+	var world Topology = Plane{}
+	if world.Length(H{2, 1}, H{10, 0}) != 8 || world.Normalize(H{100, 3}) != (H{100, 3}) {
+		t.Errorf("plane should not wrap")
+	}
+	if len(world.Range(H{0, 0}, 2)) != 19 || len(world.Ring(H{0, 0}, 2)) != 12 {
+		t.Errorf("plane areas should match Range and Ring")
+	}
+	if world.Neighbor(H{0, 0}, DirectionNegS) != (H{0, 1}) || len(world.Line(H{0, 0}, H{4, 0})) != 5 {
+		t.Errorf("plane steps should match Neighbor and Line")
+	}
+}
@@ -0,0 +1,164 @@
+package hexagolang
+
+// Reflections and transforms as described in
+// https://www.redblobgames.com/grids/hexagons/#reflection
+
+// AxisEnum is a line through a hex that reflections mirror across.
+type AxisEnum int
+
+// String returns the string name of the axis.
+func (a AxisEnum) String() string {
+	ret := "AxisUndefined"
+	switch a {
+	case AxisQ:
+		ret = "AxisQ"
+	case AxisR:
+		ret = "AxisR"
+	case AxisS:
+		ret = "AxisS"
+	case AxisQPerpendicular:
+		ret = "AxisQPerpendicular"
+	case AxisRPerpendicular:
+		ret = "AxisRPerpendicular"
+	case AxisSPerpendicular:
+		ret = "AxisSPerpendicular"
+	}
+	return ret
+}
+
+// Constants for the axes, AxisQ keeps the q coordinate and swaps r and s.
+// The perpendicular axes mirror across the line at right angles to their axis.
+const (
+	AxisQ AxisEnum = iota
+	AxisR
+	AxisS
+	AxisQPerpendicular
+	AxisRPerpendicular
+	AxisSPerpendicular
+	AxisUndefined
+)
+
+// reflections holds how many steps counter clockwise each axis is from AxisQ after mirroring.
+var reflections = []int{0, 2, 4, 3, 5, 1}
+
+// ReflectDelta mirrors a delta across the axis.
+func ReflectDelta(d D, a AxisEnum) D {
+	switch a {
+	case AxisQ:
+		return D{d.Q, d.S, d.R}
+	case AxisR:
+		return D{d.S, d.R, d.Q}
+	case AxisS:
+		return D{d.R, d.Q, d.S}
+	case AxisQPerpendicular:
+		return D{-d.Q, -d.S, -d.R}
+	case AxisRPerpendicular:
+		return D{-d.S, -d.R, -d.Q}
+	case AxisSPerpendicular:
+		return D{-d.R, -d.Q, -d.S}
+	}
+	return d
+}
+
+// Reflect mirrors one point across the axis through another point.
+func Reflect(origin, moving H, a AxisEnum) H {
+	return Add(origin, ReflectDelta(Subtract(moving, origin), a))
+}
+
+// Transform is a rotation, reflection and translation of the grid.
+// The zero value leaves everything in place.
+type Transform struct {
+	mirror bool // mirror across AxisQ first
+	steps  int  // then rotate counter clockwise around H{0, 0}
+	shift  D    // then move
+}
+
+// MakeRotation returns a transform rotating steps times counter clockwise around origin.
+// Negative steps rotate clockwise.
+func MakeRotation(origin H, steps int) Transform {
+	return MakeTranslation(Subtract(H{}, origin)).
+		Then(Transform{steps: (steps%6 + 6) % 6}).
+		Then(MakeTranslation(origin.Delta()))
+}
+
+// MakeReflection returns a transform mirroring across the axis through origin.
+func MakeReflection(origin H, a AxisEnum) Transform {
+	if a < AxisQ || a >= AxisUndefined {
+		return Transform{}
+	}
+	return MakeTranslation(Subtract(H{}, origin)).
+		Then(Transform{mirror: true, steps: reflections[a]}).
+		Then(MakeTranslation(origin.Delta()))
+}
+
+// MakeTranslation returns a transform moving by d.
+func MakeTranslation(d D) Transform {
+	return Transform{shift: d}
+}
+
+// Then returns the transform applying t followed by o.
+func (t Transform) Then(o Transform) Transform {
+	steps := t.steps
+	if o.mirror {
+		// Mirroring turns a counter clockwise rotation into a clockwise one.
+		steps = -steps
+	}
+	shift := o.Delta(t.shift)
+	return Transform{
+		mirror: t.mirror != o.mirror,
+		steps:  ((o.steps+steps)%6 + 6) % 6,
+		shift:  D{shift.Q + o.shift.Q, shift.R + o.shift.R, shift.S + o.shift.S},
+	}
+}
+
+// Hex returns the hex moved by the transform.
+func (t Transform) Hex(h H) H {
+	return Add(t.Delta(h.Delta()).Hex(), t.shift)
+}
+
+// Delta returns the delta turned by the transform, translations leave deltas alone.
+func (t Transform) Delta(d D) D {
+	if t.mirror {
+		d = ReflectDelta(d, AxisQ)
+	}
+	return RotateDelta(d, t.steps)
+}
+
+// Direction returns the direction turned by the transform.
+func (t Transform) Direction(d DirectionEnum) DirectionEnum {
+	if !t.mirror {
+		return d.Rotate(t.steps)
+	}
+	if d < DirectionPosQ || d >= DirectionUndefined {
+		return DirectionUndefined
+	}
+	after := t.Delta(NeighborDelta(d))
+	for k, n := range neighbors {
+		if n == after {
+			return DirectionEnum(k)
+		}
+	}
+	return DirectionUndefined
+}
+
+// Diagonal returns the diagonal turned by the transform.
+func (t Transform) Diagonal(d Diagonal) Diagonal {
+	if !t.mirror {
+		return d.Rotate(t.steps)
+	}
+	if d < DiagonalPosQ || d >= DiagonalUndefined {
+		return DiagonalUndefined
+	}
+	after := t.Delta(DiagonalDelta(d))
+	for k, n := range diagonals {
+		if n == after {
+			return Diagonal(k)
+		}
+	}
+	return DiagonalUndefined
+}
+
+// Region returns the region moved by the transform.
+func (t Transform) Region(r Region) Region {
+	return r.apply(t.Hex)
+}
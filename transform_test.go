@@ -0,0 +1,91 @@
+package hexagolang
+
+import (
+	"testing"
+)
+
+// I need to mirror hexagons.
+// Rational, symmetric maps and mirrored prefabs are built from reflections.
+func TestReflect(t *testing.T) {
+	origin := H{2, -1}
+	plan := []struct {
+		axis     AxisEnum
+		moving   H
+		expected H
+	}{
+		{AxisQ, H{3, -1}, H{3, -2}},
+		{AxisR, H{3, -1}, H{1, -1}},
+		{AxisS, H{3, -1}, H{2, 0}},
+		{AxisQPerpendicular, H{3, -1}, H{1, 0}},
+		{AxisRPerpendicular, H{3, -1}, H{3, -1}},
+		{AxisSPerpendicular, H{3, -1}, H{2, -2}},
+		{AxisUndefined, H{3, -1}, H{3, -1}},
+	}
+	for idx, params := range plan {
+		result := Reflect(origin, params.moving, params.axis)
+		if result != params.expected {
+			t.Errorf("index %d: %s: expected %+v, got %+v", idx, params.axis, params.expected, result)
+		}
+		if back := Reflect(origin, result, params.axis); back != params.moving {
+			t.Errorf("index %d: %s: reflecting twice should be a no-op, got %+v", idx, params.axis, back)
+		}
+		if result := MakeReflection(origin, params.axis).Hex(params.moving); result != params.expected {
+			t.Errorf("index %d: %s: transform expected %+v, got %+v", idx, params.axis, params.expected, result)
+		}
+	}
+}
+
+// I need to combine rotations, reflections and translations.
+// Rational, placing a prefab turns, mirrors and moves every hex, direction and area the same way.
+func TestTransform(t *testing.T) {
+	origin := H{1, 2}
+	moving := H{4, -1}
+	shift := D{2, -3, 1}
+
+	plan := []struct {
+		name      string
+		transform Transform
+		expected  H
+	}{
+		{"identity", Transform{}, moving},
+		{"translate", MakeTranslation(shift), Add(moving, shift)},
+		{"rotate", MakeRotation(origin, 1), RotateCounterClockwise(origin, moving)},
+		{"rotate back", MakeRotation(origin, -1), RotateClockwise(origin, moving)},
+		{"rotate twice", MakeRotation(origin, 1).Then(MakeRotation(origin, 1)), MakeRotation(origin, 2).Hex(moving)},
+		{"full turn", MakeRotation(origin, 6), moving},
+		{"rotate then move", MakeRotation(origin, 2).Then(MakeTranslation(shift)),
+			Add(RotateCounterClockwise(origin, RotateCounterClockwise(origin, moving)), shift)},
+		{"reflect then rotate", MakeReflection(origin, AxisR).Then(MakeRotation(H{}, 1)),
+			RotateCounterClockwise(H{}, Reflect(origin, moving, AxisR))},
+		{"rotate then reflect", MakeRotation(H{}, 1).Then(MakeReflection(origin, AxisS)),
+			Reflect(origin, RotateCounterClockwise(H{}, moving), AxisS)},
+	}
+	for _, params := range plan {
+		if result := params.transform.Hex(moving); result != params.expected {
+			t.Errorf("%s: expected %+v, got %+v", params.name, params.expected, result)
+		}
+	}
+
+	rotate := MakeRotation(origin, 1)
+	if d := rotate.Direction(DirectionPosQ); d != DirectionNegR {
+		t.Errorf("rotated direction: expected %s, got %s", DirectionNegR, d)
+	}
+	if d := MakeReflection(origin, AxisQ).Direction(DirectionPosQ); d != DirectionNegR {
+		t.Errorf("reflected direction: expected %s, got %s", DirectionNegR, d)
+	}
+	if d := rotate.Direction(DirectionUndefined); d != DirectionUndefined {
+		t.Errorf("undefined direction: expected %s, got %s", DirectionUndefined, d)
+	}
+	if d := rotate.Then(MakeReflection(H{}, AxisQ)).Diagonal(DiagonalNegR); d != DiagonalPosR {
+		t.Errorf("diagonal: expected %s, got %s", DiagonalPosR, d)
+	}
+	if d := MakeTranslation(shift).Delta(shift); d != shift {
+		t.Errorf("translations should leave deltas alone, got %+v", d)
+	}
+
+	area := Region(Range(H{0, 0}, 1)).Union(MakeRegion(H{2, 0}))
+	moved := MakeReflection(origin, AxisS).Region(area)
+	if !moved.Equal(area.Reflect(origin, AxisS)) {
+		t.Errorf("region: expected %+v, got %+v", area.Reflect(origin, AxisS).Hexes(), moved.Hexes())
+	}
+}
@@ -0,0 +1,72 @@
+package hexagolang
+
+import (
+	"image"
+	"math"
+)
+
+// HexesInRect returns the hexagons whose outline overlaps the screen rectangle from min to max.
+// Hexagons only touching the rectangle along a side or corner are left out.
+func (l Layout) HexesInRect(min, max F) map[H]bool {
+	result := make(map[H]bool)
+	if min.X >= max.X || min.Y >= max.Y {
+		return result
+	}
+
+	// Every overlapping hex is within one step of the corners in fractional coordinates.
+	lowQ, lowR := math.Inf(1), math.Inf(1)
+	highQ, highR := math.Inf(-1), math.Inf(-1)
+	for _, corner := range []F{min, {max.X, min.Y}, max, {min.X, max.Y}} {
+		f := l.FractionalFor(corner)
+		lowQ, highQ = math.Min(lowQ, f.Q), math.Max(highQ, f.Q)
+		lowR, highR = math.Min(lowR, f.R), math.Max(highR, f.R)
+	}
+	for r := int(math.Floor(lowR)) - 1; r <= int(math.Ceil(highR))+1; r++ {
+		for q := int(math.Floor(lowQ)) - 1; q <= int(math.Ceil(highQ))+1; q++ {
+			h := H{q, r}
+			if l.overlapsRect(h, min, max) {
+				result[h] = true
+			}
+		}
+	}
+	return result
+}
+
+// HexesInRectangle returns the hexagons overlapping the pixels of r.
+// Pixel x covers x-0.5 to x+0.5, matching AsPoint.
+func (l Layout) HexesInRectangle(r image.Rectangle) map[H]bool {
+	return l.HexesInRect(
+		F{float64(r.Min.X) - 0.5, float64(r.Min.Y) - 0.5},
+		F{float64(r.Max.X) - 0.5, float64(r.Max.Y) - 0.5},
+	)
+}
+
+// overlapEpsilon absorbs rounding so hexagons touching the rectangle don't overlap it.
+const overlapEpsilon = 1e-9
+
+// overlapsRect tests the outline of a hex against a rectangle with separating axes.
+func (l Layout) overlapsRect(h H, min, max F) bool {
+	corners := l.corners(h)
+	rect := [4]F{min, {max.X, min.Y}, max, {min.X, max.Y}}
+	axes := [5]F{{1, 0}, {0, 1}}
+	for k := 0; k < 3; k++ {
+		side := corners[k+1].Subtract(corners[k])
+		axes[k+2] = F{-side.Y, side.X}
+	}
+	for _, axis := range axes {
+		hexLow, hexHigh := math.Inf(1), math.Inf(-1)
+		for _, c := range corners {
+			p := c.X*axis.X + c.Y*axis.Y
+			hexLow, hexHigh = math.Min(hexLow, p), math.Max(hexHigh, p)
+		}
+		rectLow, rectHigh := math.Inf(1), math.Inf(-1)
+		for _, c := range rect {
+			p := c.X*axis.X + c.Y*axis.Y
+			rectLow, rectHigh = math.Min(rectLow, p), math.Max(rectHigh, p)
+		}
+		if hexHigh <= rectLow+overlapEpsilon || rectHigh <= hexLow+overlapEpsilon {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,76 @@
+package hexagolang
+
+import (
+	"image"
+	"math/rand"
+	"testing"
+)
+
+// I need to know which hex are inside the camera.
+// Rational, large maps only draw what is on the screen.
+func TestHexesInRect(t *testing.T) {
+	layout := MakeLayout(F{10, 10}, F{0, 0}, OrientationPointy)
+	result := layout.HexesInRect(F{-8.660254, -10}, F{8.660254, 10})
+	expected := []H{{0, 0}, {0, -1}, {1, -1}, {-1, 1}, {0, 1}}
+	if len(result) != len(expected) {
+		t.Errorf("expected %d hexagons, got %+v", len(expected), result)
+	}
+	for _, h := range expected {
+		if !result[h] {
+			t.Errorf("expected %+v in %+v", h, result)
+		}
+	}
+	if result := layout.HexesInRect(F{1, 1}, F{1, 5}); len(result) != 0 {
+		t.Errorf("expected an empty rectangle to have no hexagons, got %+v", result)
+	}
+	if result := layout.HexesInRectangle(image.Rect(0, 0, 1, 1)); len(result) != 1 || !result[H{0, 0}] {
+		t.Errorf("expected a single pixel to hit H{0, 0}, got %+v", result)
+	}
+}
+
+// I need culling to be exact for every layout.
+// Rational, a missing hex leaves a hole on the screen.
+func TestHexesInRectSampled(t *testing.T) {
+	layouts := []Layout{
+		MakeLayout(F{10, 10}, F{3, -4}, OrientationPointy),
+		MakeLayout(F{10, 10}, F{0, 0}, OrientationFlat),
+		MakeLayout(F{14, 6}, F{-7, 2}, OrientationPointy),
+		MakeLayout(F{5, 12}, F{0, 0}, OrientationFlat),
+	}
+	sample := func(l Layout, min, max F) map[H]bool {
+		found := map[H]bool{}
+		for y := min.Y; y <= max.Y; y += 0.25 {
+			for x := min.X; x <= max.X; x += 0.25 {
+				found[l.HexFor(F{x, y})] = true
+			}
+		}
+		return found
+	}
+	random := rand.New(rand.NewSource(7))
+	for tc, layout := range layouts {
+		for k := 0; k < 20; k++ {
+			min := F{random.Float64()*200 - 100, random.Float64()*200 - 100}
+			max := min.Add(F{random.Float64() * 80, random.Float64() * 80})
+			result := layout.HexesInRect(min, max)
+			inner := sample(layout, min.Add(F{0.01, 0.01}), max.Subtract(F{0.01, 0.01}))
+			outer := sample(layout, min.Subtract(F{0.5, 0.5}), max.Add(F{0.5, 0.5}))
+			for h := range inner {
+				if !result[h] {
+					t.Errorf("index %d-%d: missing %+v", tc, k, h)
+				}
+			}
+			for h := range result {
+				if !outer[h] {
+					t.Errorf("index %d-%d: %+v is outside the rectangle", tc, k, h)
+				}
+			}
+		}
+	}
+}
+
+func BenchmarkHexesInRect(b *testing.B) {
+	layout := MakeLayout(F{32, 32}, F{}, OrientationPointy)
+	for h := 0; h < b.N; h++ {
+		layout.HexesInRect(F{float64(h), 0}, F{float64(h) + 1920, 1080})
+	}
+}